@@ -34,5 +34,5 @@ func (b *BookmarkBlock) SetBasicBlock(block BasicBlock) Block {
 }
 
 func init() {
-	registerBlockDecoder(BlockTypeBookmark, func() Block { return &BookmarkBlock{} })
+	RegisterBlockType(BlockTypeBookmark, func() Block { return &BookmarkBlock{} })
 }
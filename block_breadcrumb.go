@@ -32,5 +32,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeBreadcrumb, func() Block { return &BreadcrumbBlock{} })
+	RegisterBlockType(BlockTypeBreadcrumb, func() Block { return &BreadcrumbBlock{} })
 }
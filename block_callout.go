@@ -25,6 +25,12 @@ func NewCalloutBlock(c Callout) *CalloutBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *CalloutBlock) GetChildren() Blocks { return b.Callout.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *CalloutBlock) ChildCount() int { return b.Callout.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *CalloutBlock) SetChildren(children Blocks) {
 	b.Callout.SetChildren(children)
@@ -50,5 +56,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeCallout, func() Block { return &CalloutBlock{} })
+	RegisterBlockType(BlockTypeCallout, func() Block { return &CalloutBlock{} })
 }
@@ -35,5 +35,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeChildDatabase, func() Block { return &ChildDataBasicBlock{} })
+	RegisterBlockType(BlockTypeChildDatabase, func() Block { return &ChildDataBasicBlock{} })
 }
@@ -10,7 +10,7 @@ type ChildPage struct {
 // ChildPageBlock is a Notion block for ChildPage
 type ChildPageBlock struct {
 	BasicBlock
-	ChildPage ChildPage `json:"child_database"`
+	ChildPage ChildPage `json:"child_page"`
 }
 
 // NewChildPageBlock returns a new ChildPageBlock with the given title
@@ -35,5 +35,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeChildPage, func() Block { return &ChildPageBlock{} })
+	RegisterBlockType(BlockTypeChildPage, func() Block { return &ChildPageBlock{} })
 }
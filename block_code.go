@@ -36,5 +36,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeCode, func() Block { return &CodeBlock{} })
+	RegisterBlockType(BlockTypeCode, func() Block { return &CodeBlock{} })
 }
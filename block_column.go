@@ -21,6 +21,12 @@ func NewColumnBlock(col Column) *ColumnBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *ColumnBlock) GetChildren() Blocks { return b.Column.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *ColumnBlock) ChildCount() int { return b.Column.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *ColumnBlock) SetChildren(children Blocks) {
 	b.Column.SetChildren(children)
@@ -46,5 +52,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeColumn, func() Block { return &ColumnBlock{} })
+	RegisterBlockType(BlockTypeColumn, func() Block { return &ColumnBlock{} })
 }
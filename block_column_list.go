@@ -24,6 +24,12 @@ func NewColumnListBlock(col ColumnList) *ColumnListBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *ColumnListBlock) GetChildren() Blocks { return b.ColumnList.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *ColumnListBlock) ChildCount() int { return b.ColumnList.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *ColumnListBlock) SetChildren(children Blocks) {
 	b.ColumnList.SetChildren(children)
@@ -49,5 +55,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeColumnList, func() Block { return &ColumnListBlock{} })
+	RegisterBlockType(BlockTypeColumnList, func() Block { return &ColumnListBlock{} })
 }
@@ -32,5 +32,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeDivider, func() Block { return &DividerBlock{} })
+	RegisterBlockType(BlockTypeDivider, func() Block { return &DividerBlock{} })
 }
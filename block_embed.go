@@ -35,5 +35,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeEmbed, func() Block { return &EmbedBlock{} })
+	RegisterBlockType(BlockTypeEmbed, func() Block { return &EmbedBlock{} })
 }
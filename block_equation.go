@@ -17,10 +17,10 @@ func (b *EquationBlock) SetBasicBlock(block BasicBlock) Block {
 }
 
 // NewEquationBlock creates a new Equation block with the given equation expression
-func NewEquationBlock(expression string) *RichText {
-	return &RichText{
-		Type: RichTextTypeEquation,
-		Equation: &Equation{
+func NewEquationBlock(expression string) *EquationBlock {
+	return &EquationBlock{
+		BasicBlock: NewBasicBlock(BlockTypeEquation),
+		Equation: Equation{
 			Expression: expression,
 		},
 	}
@@ -33,5 +33,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeEquation, func() Block { return &EquationBlock{} })
+	RegisterBlockType(BlockTypeEquation, func() Block { return &EquationBlock{} })
 }
@@ -38,7 +38,7 @@ var (
 // NewHeading1Block returns a new Heading1Block with the given heading
 func NewHeading1Block(h Heading) *Heading1Block {
 	return &Heading1Block{
-		BasicBlock: NewBasicBlock(BlockTypeColumn, h.ChildCount() > 0),
+		BasicBlock: NewBasicBlock(BlockTypeHeading1, h.ChildCount() > 0),
 		Heading1:   h,
 	}
 }
@@ -46,7 +46,7 @@ func NewHeading1Block(h Heading) *Heading1Block {
 // NewHeading2Block returns a new Heading2Block with the given heading
 func NewHeading2Block(h Heading) *Heading2Block {
 	return &Heading2Block{
-		BasicBlock: NewBasicBlock(BlockTypeColumn, h.ChildCount() > 0),
+		BasicBlock: NewBasicBlock(BlockTypeHeading2, h.ChildCount() > 0),
 		Heading2:   h,
 	}
 }
@@ -54,7 +54,7 @@ func NewHeading2Block(h Heading) *Heading2Block {
 // NewHeading3Block returns a new Heading3Block with the given heading
 func NewHeading3Block(h Heading) *Heading3Block {
 	return &Heading3Block{
-		BasicBlock: NewBasicBlock(BlockTypeColumn, h.ChildCount() > 0),
+		BasicBlock: NewBasicBlock(BlockTypeHeading3, h.ChildCount() > 0),
 		Heading3:   h,
 	}
 }
@@ -76,6 +76,12 @@ func NewHeadingBlock(heading Heading, level int) Block {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *Heading1Block) GetChildren() Blocks { return b.Heading1.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *Heading1Block) ChildCount() int { return b.Heading1.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *Heading1Block) SetChildren(children Blocks) {
 	b.Heading1.SetChildren(children)
@@ -88,6 +94,12 @@ func (b *Heading1Block) AppendChildren(children ...Block) {
 	b.HasChildren = b.Heading1.ChildCount() > 0
 }
 
+// GetChildren returns the inner children
+func (b *Heading2Block) GetChildren() Blocks { return b.Heading2.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *Heading2Block) ChildCount() int { return b.Heading2.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *Heading2Block) SetChildren(children Blocks) {
 	b.Heading2.SetChildren(children)
@@ -100,6 +112,12 @@ func (b *Heading2Block) AppendChildren(children ...Block) {
 	b.HasChildren = b.Heading2.ChildCount() > 0
 }
 
+// GetChildren returns the inner children
+func (b *Heading3Block) GetChildren() Blocks { return b.Heading3.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *Heading3Block) ChildCount() int { return b.Heading3.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *Heading3Block) SetChildren(children Blocks) {
 	b.Heading3.SetChildren(children)
@@ -145,7 +163,7 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeHeading1, func() Block { return &Heading1Block{} })
-	registerBlockDecoder(BlockTypeHeading2, func() Block { return &Heading2Block{} })
-	registerBlockDecoder(BlockTypeHeading3, func() Block { return &Heading3Block{} })
+	RegisterBlockType(BlockTypeHeading1, func() Block { return &Heading1Block{} })
+	RegisterBlockType(BlockTypeHeading2, func() Block { return &Heading2Block{} })
+	RegisterBlockType(BlockTypeHeading3, func() Block { return &Heading3Block{} })
 }
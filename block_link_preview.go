@@ -36,5 +36,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeLinkPreview, func() Block { return &LinkPreviewBlock{} })
+	RegisterBlockType(BlockTypeLinkPreview, func() Block { return &LinkPreviewBlock{} })
 }
@@ -64,5 +64,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeLinkToPage, func() Block { return &LinkToPageBlock{} })
+	RegisterBlockType(BlockTypeLinkToPage, func() Block { return &LinkToPageBlock{} })
 }
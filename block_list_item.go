@@ -38,6 +38,12 @@ func NewNumberedListItemBlock(li ListItem) *NumberedListItemBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *BulletedListItemBlock) GetChildren() Blocks { return b.BulletedListItem.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *BulletedListItemBlock) ChildCount() int { return b.BulletedListItem.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *BulletedListItemBlock) SetChildren(children Blocks) {
 	b.BulletedListItem.SetChildren(children)
@@ -50,6 +56,12 @@ func (b *BulletedListItemBlock) AppendChildren(children ...Block) {
 	b.HasChildren = b.BulletedListItem.ChildCount() > 0
 }
 
+// GetChildren returns the inner children
+func (b *NumberedListItemBlock) GetChildren() Blocks { return b.NumberedListItem.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *NumberedListItemBlock) ChildCount() int { return b.NumberedListItem.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *NumberedListItemBlock) SetChildren(children Blocks) {
 	b.NumberedListItem.SetChildren(children)
@@ -85,6 +97,6 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeBulletedListItem, func() Block { return &BulletedListItemBlock{} })
-	registerBlockDecoder(BlockTypeNumberedListItem, func() Block { return &NumberedListItemBlock{} })
+	RegisterBlockType(BlockTypeBulletedListItem, func() Block { return &BulletedListItemBlock{} })
+	RegisterBlockType(BlockTypeNumberedListItem, func() Block { return &NumberedListItemBlock{} })
 }
@@ -182,9 +182,9 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeFile, func() Block { return &FileBlock{} })
-	registerBlockDecoder(BlockTypePdf, func() Block { return &PdfBlock{} })
-	registerBlockDecoder(BlockTypeImage, func() Block { return &ImageBlock{} })
-	registerBlockDecoder(BlockTypeAudio, func() Block { return &AudioBlock{} })
-	registerBlockDecoder(BlockTypeVideo, func() Block { return &VideoBlock{} })
+	RegisterBlockType(BlockTypeFile, func() Block { return &FileBlock{} })
+	RegisterBlockType(BlockTypePdf, func() Block { return &PdfBlock{} })
+	RegisterBlockType(BlockTypeImage, func() Block { return &ImageBlock{} })
+	RegisterBlockType(BlockTypeAudio, func() Block { return &AudioBlock{} })
+	RegisterBlockType(BlockTypeVideo, func() Block { return &VideoBlock{} })
 }
@@ -23,6 +23,12 @@ func NewParagraphBlock(p Paragraph) *ParagraphBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *ParagraphBlock) GetChildren() Blocks { return b.Paragraph.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *ParagraphBlock) ChildCount() int { return b.Paragraph.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *ParagraphBlock) SetChildren(children Blocks) {
 	b.Paragraph.SetChildren(children)
@@ -48,5 +54,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeParagraph, func() Block { return &ParagraphBlock{} })
+	RegisterBlockType(BlockTypeParagraph, func() Block { return &ParagraphBlock{} })
 }
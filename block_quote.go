@@ -24,6 +24,12 @@ func NewQuoteBlock(q Quote) *QuoteBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *QuoteBlock) GetChildren() Blocks { return b.Quote.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *QuoteBlock) ChildCount() int { return b.Quote.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *QuoteBlock) SetChildren(children Blocks) {
 	b.Quote.SetChildren(children)
@@ -49,5 +55,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeQuote, func() Block { return &QuoteBlock{} })
+	RegisterBlockType(BlockTypeQuote, func() Block { return &QuoteBlock{} })
 }
@@ -1,5 +1,7 @@
 package notion
 
+import "strings"
+
 // Reference: https://developers.notion.com/reference/rich-text
 //            https://developers.notion.com/reference/rich-text#the-annotation-object
 
@@ -35,6 +37,23 @@ type RichText struct {
 // RichTexts is a slice of RichText
 type RichTexts []RichText
 
+// PlainText returns the concatenated plain texts of the RichTexts.
+// For rich texts built locally (without PlainText), the text content or equation expression is used.
+func (rts RichTexts) PlainText() string {
+	var sb strings.Builder
+	for _, rt := range rts {
+		switch {
+		case rt.PlainText != "":
+			sb.WriteString(rt.PlainText)
+		case rt.Text != nil:
+			sb.WriteString(rt.Text.Content)
+		case rt.Equation != nil:
+			sb.WriteString(rt.Equation.Expression)
+		}
+	}
+	return sb.String()
+}
+
 // Annotations is a set of annotations for RichText
 type Annotations struct {
 	Bold          bool  `json:"bold"`
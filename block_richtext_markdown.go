@@ -0,0 +1,311 @@
+package notion
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseInlineMarkdown parses the inline markdown of s into RichTexts. It supports:
+//   - **bold** and __bold__;
+//   - *italic* and _italic_ (underscores don't work inside words, e.g. snake_case_name);
+//   - ~~strikethrough~~;
+//   - `inline code` (its content is taken as is);
+//   - [links](https://example.com) (their text can be formatted);
+//   - $equations$ (their content is taken as is, except for the escaped \$);
+//   - backslash escapes of ASCII punctuation, e.g. \* or \[.
+//
+// Formatting can be nested, e.g. **bold *and italic***. Unmatched delimiters are kept as text.
+// The rich texts are built as NewTextRichText, NewLinkRichText and NewEquationRichText
+// with WithBold, WithItalic, WithStrikethrough and WithCode would build them.
+func ParseInlineMarkdown(s string) RichTexts {
+	p := &inlineParser{texts: RichTexts{}}
+	p.parse(s, inlineStyle{})
+	return p.texts
+}
+
+// inlineStyle is the formatting of a span of inline markdown.
+type inlineStyle struct {
+	bold          bool
+	italic        bool
+	strikethrough bool
+	link          string
+}
+
+// annotate applies the style to the rich text.
+func (s inlineStyle) annotate(rt RichText) RichText {
+	if s.bold {
+		rt = rt.WithBold()
+	}
+	if s.italic {
+		rt = rt.WithItalic()
+	}
+	if s.strikethrough {
+		rt = rt.WithStrikethrough()
+	}
+	return rt
+}
+
+// text makes the rich text of the content in the style.
+func (s inlineStyle) text(content string) RichText {
+	if s.link != "" {
+		return s.annotate(NewLinkRichText(content, s.link))
+	}
+	return s.annotate(NewTextRichText(content))
+}
+
+// inlineParser holds the state of a single ParseInlineMarkdown call.
+type inlineParser struct {
+	texts RichTexts
+	// buf is the text (in the style of the span being parsed) which isn't added to texts yet.
+	buf strings.Builder
+}
+
+// flush adds the buffered text in the style.
+func (p *inlineParser) flush(style inlineStyle) {
+	if p.buf.Len() == 0 {
+		return
+	}
+	p.texts = append(p.texts, style.text(p.buf.String()))
+	p.buf.Reset()
+}
+
+// parse parses the span of inline markdown in the style.
+func (p *inlineParser) parse(s string, style inlineStyle) {
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) && isMarkdownPunct(s[i+1]) {
+				p.buf.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+		case '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				p.flush(style)
+				p.texts = append(p.texts, style.text(s[i+1:i+1+end]).WithCode())
+				i += end + 2
+				continue
+			}
+		case '$':
+			if end := closingDollar(s, i+1); end > 0 {
+				p.flush(style)
+				expression := strings.ReplaceAll(s[i+1:end], `\$`, "$")
+				p.texts = append(p.texts, style.annotate(*NewEquationRichText(expression)))
+				i = end + 1
+				continue
+			}
+		case '[':
+			if text, link, end := markdownLink(s, i); end > 0 {
+				p.flush(style)
+				linkStyle := style
+				linkStyle.link = link
+				p.parse(text, linkStyle)
+				i = end
+				continue
+			}
+		case '*', '_', '~':
+			if delim, end := closingDelimiter(s, i); end > 0 {
+				p.flush(style)
+				innerStyle := style
+				switch delim {
+				case "**", "__":
+					innerStyle.bold = true
+				case "*", "_":
+					innerStyle.italic = true
+				case "~~":
+					innerStyle.strikethrough = true
+				}
+				p.parse(s[i+len(delim):end], innerStyle)
+				i = end + len(delim)
+				continue
+			}
+		}
+
+		p.buf.WriteByte(s[i])
+		i++
+	}
+	p.flush(style)
+}
+
+// closingDelimiter finds the emphasis delimiter opening at i and its closing position.
+// It returns -1 if the delimiter isn't closed (or can't open or close an emphasis).
+// Code spans and links take precedence over the delimiters, e.g. *a [b*](https://example.com) c*.
+func closingDelimiter(s string, i int) (delim string, end int) {
+	c := s[i]
+	run := delimiterRun(s, i)
+	switch {
+	case run >= 2:
+		delim = s[i : i+2]
+	case c == '~':
+		return "", -1
+	default:
+		delim = s[i : i+1]
+	}
+
+	start := i + len(delim)
+	if !canOpen(s, i, start) {
+		return "", -1
+	}
+
+	for j := start; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			if codeEnd := strings.IndexByte(s[j+1:], '`'); codeEnd > 0 {
+				j += codeEnd + 2
+				continue
+			}
+		case '[':
+			if _, _, linkEnd := markdownLink(s, j); linkEnd > 0 {
+				j = linkEnd
+				continue
+			}
+		case c:
+			// The delimiters in the run closing a double delimiter are at its end, e.g. **a *b***,
+			// and a single delimiter can't be closed by a (part of) double one, e.g. *a **b** c*.
+			n := delimiterRun(s, j)
+			closing := -1
+			switch {
+			case len(delim) == 2 && n >= 2:
+				closing = j + n - 2
+			case len(delim) == 1 && n%2 == 1:
+				closing = j + n - 1
+			}
+			if closing > start && canClose(s, closing, closing+len(delim)) {
+				return delim, closing
+			}
+			j += n
+			continue
+		}
+		j++
+	}
+
+	return "", -1
+}
+
+// delimiterRun returns the number of the same delimiters starting at i.
+func delimiterRun(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// canOpen returns true if the delimiter at s[i:start] can open an emphasis:
+// it's followed by a non-space, and underscores aren't preceded by a letter or a digit.
+func canOpen(s string, i, start int) bool {
+	next, _ := utf8.DecodeRuneInString(s[start:])
+	if start == len(s) || unicode.IsSpace(next) {
+		return false
+	}
+	if s[i] == '_' && i > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		return !isWordRune(prev)
+	}
+	return true
+}
+
+// canClose returns true if the delimiter at s[i:end] can close an emphasis:
+// it's preceded by a non-space, and underscores aren't followed by a letter or a digit.
+func canClose(s string, i, end int) bool {
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	if unicode.IsSpace(prev) {
+		return false
+	}
+	if s[i] == '_' && end < len(s) {
+		next, _ := utf8.DecodeRuneInString(s[end:])
+		return !isWordRune(next)
+	}
+	return true
+}
+
+// closingDollar returns the position of the $ closing the equation starting at i, or -1.
+// The equation can't start or end with a space, and its closing $ can't be followed by a digit,
+// so the prices like $5 and $6 stay text.
+func closingDollar(s string, i int) int {
+	if i == len(s) || s[i] == ' ' || s[i] == '$' {
+		return -1
+	}
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '$':
+			if s[j-1] == ' ' || j+1 < len(s) && s[j+1] >= '0' && s[j+1] <= '9' {
+				return -1
+			}
+			return j
+		}
+	}
+	return -1
+}
+
+// markdownLink parses the [text](link) starting at i. It returns the end of the link, or -1.
+// Code spans take precedence over the brackets of the text, and the parentheses of the link must be balanced,
+// e.g. [Go](https://en.wikipedia.org/wiki/Go_(programming_language)).
+func markdownLink(s string, i int) (text, link string, end int) {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if codeEnd := strings.IndexByte(s[j+1:], '`'); codeEnd > 0 {
+				j += codeEnd + 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if j == i+1 || j+1 == len(s) || s[j+1] != '(' {
+				return "", "", -1
+			}
+			linkEnd := closingParen(s, j+2)
+			if linkEnd < 0 {
+				return "", "", -1
+			}
+			link = strings.TrimSpace(s[j+2 : linkEnd])
+			if link == "" || strings.ContainsAny(link, " \t\n") {
+				return "", "", -1
+			}
+			return s[i+1 : j], link, linkEnd + 1
+		}
+	}
+	return "", "", -1
+}
+
+// closingParen returns the position of the ) closing the link destination starting at i, or -1.
+func closingParen(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return j
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// isMarkdownPunct returns true if the byte is an ASCII punctuation character which can be escaped.
+func isMarkdownPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+// isWordRune returns true if the rune is a letter or a digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
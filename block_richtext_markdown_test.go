@@ -0,0 +1,117 @@
+package notion_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func TestParseInlineMarkdown(t *testing.T) {
+	text := notion.NewTextRichText
+	equation := func(expression string) notion.RichText { return *notion.NewEquationRichText(expression) }
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     notion.RichTexts
+	}{
+		{name: "empty", markdown: "", want: notion.RichTexts{}},
+		{name: "plain", markdown: "plain text", want: notion.RichTexts{text("plain text")}},
+		{
+			name:     "bold",
+			markdown: "a **bold** and __bold__",
+			want: notion.RichTexts{
+				text("a "), text("bold").WithBold(), text(" and "), text("bold").WithBold(),
+			},
+		},
+		{
+			name:     "italic",
+			markdown: "*italic* and _italic_",
+			want:     notion.RichTexts{text("italic").WithItalic(), text(" and "), text("italic").WithItalic()},
+		},
+		{
+			name:     "strikethrough",
+			markdown: "~~gone~~ ~kept~",
+			want:     notion.RichTexts{text("gone").WithStrikethrough(), text(" ~kept~")},
+		},
+		{
+			name:     "code",
+			markdown: "run `go *test*` now",
+			want:     notion.RichTexts{text("run "), text("go *test*").WithCode(), text(" now")},
+		},
+		{
+			name:     "links",
+			markdown: "see [the docs](https://example.com) or [**bold** link](https://example.com/b)",
+			want: notion.RichTexts{
+				text("see "),
+				notion.NewLinkRichText("the docs", "https://example.com"),
+				text(" or "),
+				notion.NewLinkRichText("bold", "https://example.com/b").WithBold(),
+				notion.NewLinkRichText(" link", "https://example.com/b"),
+			},
+		},
+		{
+			name:     "links with parentheses",
+			markdown: "[wiki](https://en.wikipedia.org/wiki/Go_(language)) (see above)",
+			want: notion.RichTexts{
+				notion.NewLinkRichText("wiki", "https://en.wikipedia.org/wiki/Go_(language)"),
+				text(" (see above)"),
+			},
+		},
+		{
+			name:     "links and code spans take precedence",
+			markdown: "*a [b*](https://x) c* [`]`](https://y)",
+			want: notion.RichTexts{
+				text("a ").WithItalic(),
+				notion.NewLinkRichText("b*", "https://x").WithItalic(),
+				text(" c").WithItalic(),
+				text(" "),
+				notion.NewLinkRichText("]", "https://y").WithCode(),
+			},
+		},
+		{
+			name:     "equations",
+			markdown: `$e=mc^2$ costs $5 and $6, not $\$x$`,
+			want:     notion.RichTexts{equation("e=mc^2"), text(" costs $5 and $6, not "), equation("$x")},
+		},
+		{
+			name:     "nested",
+			markdown: "**bold *and italic***, ***both***, *italic **and bold** again*",
+			want: notion.RichTexts{
+				text("bold ").WithBold(),
+				text("and italic").WithBold().WithItalic(),
+				text(", "),
+				text("both").WithBold().WithItalic(),
+				text(", "),
+				text("italic ").WithItalic(),
+				text("and bold").WithItalic().WithBold(),
+				text(" again").WithItalic(),
+			},
+		},
+		{
+			name:     "formatted code and equations",
+			markdown: "**`code` $x$**",
+			want: notion.RichTexts{
+				text("code").WithBold().WithCode(), text(" ").WithBold(), equation("x").WithBold(),
+			},
+		},
+		{
+			name:     "escapes",
+			markdown: `\*not italic\*, \[not](a link), \$5\$ and C:\path`,
+			want:     notion.RichTexts{text(`*not italic*, [not](a link), $5$ and C:\path`)},
+		},
+		{
+			name:     "unmatched delimiters",
+			markdown: "2 * 3 * 4, **open, snake_case_name, [text](), `open, $ x$",
+			want:     notion.RichTexts{text("2 * 3 * 4, **open, snake_case_name, [text](), `open, $ x$")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, notion.ParseInlineMarkdown(tt.markdown))
+		})
+	}
+}
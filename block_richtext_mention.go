@@ -67,7 +67,7 @@ type TemplateMention struct {
 // NewDatabaseMentionRichText creates a new RichText with mention to the given database ID
 func NewDatabaseMentionRichText(databaseID ObjectID) *RichText {
 	return &RichText{
-		Type: RichTextTypeText,
+		Type: RichTextTypeMention,
 		Mention: &Mention{
 			Type: MentionTypeDatabase,
 			Database: &DatabaseMention{
@@ -80,7 +80,7 @@ func NewDatabaseMentionRichText(databaseID ObjectID) *RichText {
 // NewPageMentionRichText creates a new RichText with mention to the given page ID
 func NewPageMentionRichText(pageID ObjectID) *RichText {
 	return &RichText{
-		Type: RichTextTypeText,
+		Type: RichTextTypeMention,
 		Mention: &Mention{
 			Type: MentionTypePage,
 			Page: &PageMention{ID: pageID},
@@ -91,7 +91,7 @@ func NewPageMentionRichText(pageID ObjectID) *RichText {
 // NewUserMentionRichText creates a new RichText with mention to the given user ID
 func NewUserMentionRichText(userID ObjectID) *RichText {
 	return &RichText{
-		Type: RichTextTypeText,
+		Type: RichTextTypeMention,
 		Mention: &Mention{
 			Type: MentionTypeUser,
 			User: &UserMention{ID: userID},
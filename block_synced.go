@@ -29,6 +29,12 @@ func NewSyncedBlock(s Synced) *SyncedBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *SyncedBlock) GetChildren() Blocks { return b.Synced.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *SyncedBlock) ChildCount() int { return b.Synced.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *SyncedBlock) SetChildren(children Blocks) {
 	b.Synced.SetChildren(children)
@@ -54,5 +60,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeSyncedBlock, func() Block { return &SyncedBlock{} })
+	RegisterBlockType(BlockTypeSyncedBlock, func() Block { return &SyncedBlock{} })
 }
@@ -44,6 +44,12 @@ func NewTableBlock(table Table) *TableBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *TableBlock) GetChildren() Blocks { return b.Table.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *TableBlock) ChildCount() int { return b.Table.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *TableBlock) SetChildren(children Blocks) {
 	b.Table.SetChildren(children)
@@ -79,6 +85,6 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeTable, func() Block { return &TableBlock{} })
-	registerBlockDecoder(BlockTypeTableRow, func() Block { return &TableRowBlock{} })
+	RegisterBlockType(BlockTypeTable, func() Block { return &TableBlock{} })
+	RegisterBlockType(BlockTypeTableRow, func() Block { return &TableRowBlock{} })
 }
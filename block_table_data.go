@@ -0,0 +1,134 @@
+package notion
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidTable is returned for tables (or table data) Notion can't hold.
+var ErrInvalidTable = errors.New("invalid table")
+
+// TableOptions configures the tables made from table data.
+type TableOptions struct {
+	// HasColumnHeader makes the first row the header of the columns.
+	HasColumnHeader bool
+	// HasRowHeader makes the first column the header of the rows.
+	HasRowHeader bool
+}
+
+// NewTableBlockFromRichTexts creates a new TableBlock with a row for each of the given rows of cells.
+// All rows must have the same number of cells: it becomes the TableWidth (which can't be changed later).
+func NewTableBlockFromRichTexts(rows [][]RichTexts, opts *TableOptions) (*TableBlock, error) {
+	if opts == nil {
+		opts = &TableOptions{}
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("%w: no cells", ErrInvalidTable)
+	}
+
+	width := len(rows[0])
+	tableRows := make(Blocks, len(rows))
+	for i, row := range rows {
+		if len(row) != width {
+			return nil, fmt.Errorf("%w: row %d has %d cells, want %d", ErrInvalidTable, i, len(row), width)
+		}
+
+		cells := make([]RichTexts, width)
+		for j, cell := range row {
+			if cell == nil {
+				cell = RichTexts{}
+			}
+			cells[j] = cell
+		}
+		tableRows[i] = NewTableRowBlock(TableRow{Cells: cells})
+	}
+
+	return NewTableBlock(Table{
+		AtomChildren:    AtomChildren{Children: tableRows},
+		TableWidth:      width,
+		HasColumnHeader: opts.HasColumnHeader,
+		HasRowHeader:    opts.HasRowHeader,
+	}), nil
+}
+
+// NewTableBlockFromStrings creates a new TableBlock with plain text cells. See NewTableBlockFromRichTexts.
+func NewTableBlockFromStrings(rows [][]string, opts *TableOptions) (*TableBlock, error) {
+	richRows := make([][]RichTexts, len(rows))
+	for i, row := range rows {
+		richRows[i] = make([]RichTexts, len(row))
+		for j, cell := range row {
+			richRows[i][j] = RichTexts{}
+			if cell != "" {
+				richRows[i][j] = RichTexts{NewTextRichText(cell)}
+			}
+		}
+	}
+	return NewTableBlockFromRichTexts(richRows, opts)
+}
+
+// NewTableBlockFromCSV creates a new TableBlock with plain text cells from the CSV records.
+// See NewTableBlockFromRichTexts.
+func NewTableBlockFromCSV(r io.Reader, opts *TableOptions) (*TableBlock, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // the widths are checked with a better error
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return NewTableBlockFromStrings(records, opts)
+}
+
+// RichTexts returns the cells of the rows of the table.
+// The table must be fetched with its rows (e.g. by BlocksService.GetTree),
+// and the rows must have TableWidth cells.
+func (b *TableBlock) RichTexts() ([][]RichTexts, error) {
+	children := b.GetChildren()
+	if len(children) == 0 && b.HasChildren {
+		return nil, fmt.Errorf("%w: rows are not fetched", ErrInvalidTable)
+	}
+
+	rows := make([][]RichTexts, len(children))
+	for i, child := range children {
+		row, ok := child.(*TableRowBlock)
+		if !ok {
+			return nil, fmt.Errorf("%w: row %d is %s", ErrInvalidTable, i, child.GetType())
+		}
+		if len(row.TableRow.Cells) != b.Table.TableWidth {
+			return nil, fmt.Errorf("%w: row %d has %d cells, want %d",
+				ErrInvalidTable, i, len(row.TableRow.Cells), b.Table.TableWidth)
+		}
+		rows[i] = row.TableRow.Cells
+	}
+
+	return rows, nil
+}
+
+// Strings returns the plain texts of the cells of the rows of the table. See RichTexts.
+func (b *TableBlock) Strings() ([][]string, error) {
+	richRows, err := b.RichTexts()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, len(richRows))
+	for i, row := range richRows {
+		rows[i] = make([]string, len(row))
+		for j, cell := range row {
+			rows[i][j] = cell.PlainText()
+		}
+	}
+	return rows, nil
+}
+
+// WriteCSV writes the plain texts of the cells of the table as CSV records. See RichTexts.
+func (b *TableBlock) WriteCSV(w io.Writer) error {
+	rows, err := b.Strings()
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	return writer.WriteAll(rows)
+}
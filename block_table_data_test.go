@@ -0,0 +1,90 @@
+package notion_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func TestTableBlock_CSV(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	page := newTestPage(t, srv, "Report")
+
+	const report = "Name,Total\n\"Smith, J.\",42\nDoe,\n"
+	table, err := notion.NewTableBlockFromCSV(strings.NewReader(report), &notion.TableOptions{HasColumnHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, table.Table.TableWidth)
+	assert.True(t, table.Table.HasColumnHeader)
+	assert.False(t, table.Table.HasRowHeader)
+	assert.True(t, table.GetHasChildren())
+
+	_, err = client.Blocks.AppendTree(ctx, page.ID, notion.Blocks{table}, nil)
+	require.NoError(t, err)
+
+	tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	fetched := tree[0].(*notion.TableBlock)
+	assert.True(t, fetched.Table.HasColumnHeader)
+
+	rows, err := fetched.Strings()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Total"}, {"Smith, J.", "42"}, {"Doe", ""}}, rows)
+
+	var out bytes.Buffer
+	require.NoError(t, fetched.WriteCSV(&out))
+	assert.Equal(t, report, out.String())
+}
+
+func TestNewTableBlockFromRichTexts(t *testing.T) {
+	t.Run("should keep the rich texts", func(t *testing.T) {
+		cells := [][]notion.RichTexts{
+			{{notion.NewTextRichText("a").WithBold()}, {notion.NewTextRichText("b"), notion.NewTextRichText("c")}},
+			{nil, {*notion.NewEquationRichText("x^2")}},
+		}
+		table, err := notion.NewTableBlockFromRichTexts(cells, &notion.TableOptions{HasRowHeader: true})
+		require.NoError(t, err)
+		assert.True(t, table.Table.HasRowHeader)
+
+		richRows, err := table.RichTexts()
+		require.NoError(t, err)
+		assert.True(t, richRows[0][0][0].Annotations.Bold)
+		assert.Equal(t, notion.RichTexts{}, richRows[1][0])
+
+		rows, err := table.Strings()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "bc"}, {"", "x^2"}}, rows)
+	})
+
+	t.Run("should reject invalid tables", func(t *testing.T) {
+		_, err := notion.NewTableBlockFromStrings(nil, nil)
+		require.ErrorIs(t, err, notion.ErrInvalidTable)
+
+		_, err = notion.NewTableBlockFromStrings([][]string{{"a", "b"}, {"c"}}, nil)
+		require.ErrorIs(t, err, notion.ErrInvalidTable)
+		assert.EqualError(t, err, "invalid table: row 1 has 1 cells, want 2")
+
+		_, err = notion.NewTableBlockFromCSV(strings.NewReader("a,b\n\"c\n"), nil)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject tables without rows or with bad rows", func(t *testing.T) {
+		table := notion.NewTableBlock(notion.Table{TableWidth: 2})
+		table.HasChildren = true
+		_, err := table.RichTexts()
+		require.ErrorIs(t, err, notion.ErrInvalidTable)
+
+		table.SetChildren(notion.Blocks{notion.NewTableRowBlock(notion.TableRow{Cells: []notion.RichTexts{{}}})})
+		_, err = table.Strings()
+		require.ErrorIs(t, err, notion.ErrInvalidTable)
+	})
+}
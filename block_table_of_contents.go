@@ -35,5 +35,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeTableOfContents, func() Block { return &TableOfContentsBlock{} })
+	RegisterBlockType(BlockTypeTableOfContents, func() Block { return &TableOfContentsBlock{} })
 }
@@ -26,6 +26,12 @@ func NewTemplateBlock(t Template) *TemplateBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *TemplateBlock) GetChildren() Blocks { return b.Template.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *TemplateBlock) ChildCount() int { return b.Template.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *TemplateBlock) SetChildren(children Blocks) {
 	b.Template.SetChildren(children)
@@ -51,5 +57,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeTemplate, func() Block { return &TemplateBlock{} })
+	RegisterBlockType(BlockTypeTemplate, func() Block { return &TemplateBlock{} })
 }
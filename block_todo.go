@@ -24,6 +24,12 @@ func NewToDoBlock(t ToDo) *ToDoBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *ToDoBlock) GetChildren() Blocks { return b.ToDo.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *ToDoBlock) ChildCount() int { return b.ToDo.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *ToDoBlock) SetChildren(children Blocks) {
 	b.ToDo.SetChildren(children)
@@ -49,5 +55,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeToDo, func() Block { return &ToDoBlock{} })
+	RegisterBlockType(BlockTypeToDo, func() Block { return &ToDoBlock{} })
 }
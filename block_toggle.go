@@ -23,6 +23,12 @@ func NewToggleBlock(t Toggle) *ToggleBlock {
 	}
 }
 
+// GetChildren returns the inner children
+func (b *ToggleBlock) GetChildren() Blocks { return b.Toggle.GetChildren() }
+
+// ChildCount returns the number of the inner children
+func (b *ToggleBlock) ChildCount() int { return b.Toggle.ChildCount() }
+
 // SetChildren calls inner .SetChildren + updates the HasChildren field
 func (b *ToggleBlock) SetChildren(children Blocks) {
 	b.Toggle.SetChildren(children)
@@ -48,5 +54,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeToggle, func() Block { return &ToggleBlock{} })
+	RegisterBlockType(BlockTypeToggle, func() Block { return &ToggleBlock{} })
 }
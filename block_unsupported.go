@@ -1,13 +1,59 @@
 package notion
 
-// UnsupportedBlock is a Notion block for unsupported blocks
+import "encoding/json"
+
+// basicBlockFields are the JSON fields of BasicBlock.
+var basicBlockFields = []string{
+	"object", "id", "parent", "type", "created_time", "created_by", "last_edited_time", "last_edited_by",
+	"archived", "in_trash", "icon", "cover", "has_children",
+}
+
+// UnsupportedBlock is a Notion block for unsupported blocks:
+// the blocks of "unsupported" type and of the types not registered with RegisterBlockType.
 type UnsupportedBlock struct {
 	BasicBlock
+
+	// Raw is the original JSON of the decoded block. It's written back by MarshalJSON,
+	// so the content of unknown block types survives a read -> modify -> write round trip.
+	Raw json.RawMessage `json:"-"`
 }
 
 // NewUnsupportedBlock creates a new UnsupportedBlock
 func NewUnsupportedBlock() *UnsupportedBlock {
-	return &UnsupportedBlock{NewBasicBlock(BlockTypeUnsupported)}
+	return &UnsupportedBlock{BasicBlock: NewBasicBlock(BlockTypeUnsupported)}
+}
+
+// Content returns the original JSON of the type-specific content of the block (nil if there is none).
+func (b *UnsupportedBlock) Content() json.RawMessage {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b.Raw, &raw); err != nil {
+		return nil
+	}
+	return raw[string(b.Type)]
+}
+
+// MarshalJSON writes the original JSON of the block (see Raw) with the current BasicBlock fields.
+func (b UnsupportedBlock) MarshalJSON() ([]byte, error) {
+	basic, err := json.Marshal(b.BasicBlock)
+	if err != nil || b.Raw == nil {
+		return basic, err
+	}
+
+	var raw, fields map[string]json.RawMessage
+	if err := json.Unmarshal(b.Raw, &raw); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(basic, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range basicBlockFields {
+		delete(raw, field)
+	}
+	for field, value := range fields {
+		raw[field] = value
+	}
+
+	return json.Marshal(raw)
 }
 
 // SetBasicBlock implements the SetBasicBlock method of the BasicBlockHolder interface.
@@ -23,5 +69,5 @@ var (
 )
 
 func init() {
-	registerBlockDecoder(BlockTypeUnsupported, func() Block { return &UnsupportedBlock{} })
+	RegisterBlockType(BlockTypeUnsupported, func() Block { return &UnsupportedBlock{} })
 }
@@ -0,0 +1,330 @@
+// Package build provides a fluent builder of Notion block trees.
+//
+// Instead of nesting the constructors of the notion package, the blocks are listed in order:
+//
+//	blocks := build.Doc().
+//		H1("Title").
+//		P("Some ", build.Bold("bold"), " text").
+//		Toggle("More", func(b *build.Builder) {
+//			b.Bullet("first").Bullet("second")
+//		}).
+//		Table([][]any{{"Name", "Value"}, {"a", 1}}).
+//		Blocks()
+//
+// Most methods take the content of the block as a list of parts:
+//   - strings, notion.RichText, *notion.RichText and notion.RichTexts make the text of the block
+//     (see Text);
+//   - notion.Color sets the color of the block;
+//   - func(*Builder), notion.Block and notion.Blocks add children to the block.
+//
+// Unsupported parts are programming errors, so they panic.
+package build
+
+import notion "github.com/amberpixels/notion-sdk-go"
+
+// Builder builds a list of blocks. Its methods append a block and return the Builder for chaining.
+type Builder struct {
+	blocks notion.Blocks
+}
+
+// Doc returns a new empty Builder.
+func Doc() *Builder {
+	return &Builder{}
+}
+
+// Blocks returns the built blocks.
+func (b *Builder) Blocks() notion.Blocks {
+	return b.blocks
+}
+
+// Add appends the given blocks.
+func (b *Builder) Add(blocks ...notion.Block) *Builder {
+	b.blocks = append(b.blocks, blocks...)
+	return b
+}
+
+// P appends a paragraph.
+func (b *Builder) P(parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewParagraphBlock(notion.Paragraph{RichText: c.text, Color: c.color}), c)
+}
+
+// H1 appends a heading of level 1.
+func (b *Builder) H1(parts ...any) *Builder { return b.heading(1, false, parts) }
+
+// H2 appends a heading of level 2.
+func (b *Builder) H2(parts ...any) *Builder { return b.heading(2, false, parts) }
+
+// H3 appends a heading of level 3.
+func (b *Builder) H3(parts ...any) *Builder { return b.heading(3, false, parts) }
+
+// ToggleHeading appends a toggleable heading of the given level (1-3). Its children are hidden under the toggle.
+func (b *Builder) ToggleHeading(level int, parts ...any) *Builder {
+	return b.heading(level, true, parts)
+}
+
+func (b *Builder) heading(level int, toggleable bool, parts []any) *Builder {
+	c := parse(parts)
+	heading := notion.Heading{RichText: c.text, Color: c.color, IsToggleable: toggleable}
+	return b.add(notion.NewHeadingBlock(heading, level), c)
+}
+
+// Bullet appends a bulleted list item.
+func (b *Builder) Bullet(parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewBulletedListItemBlock(notion.ListItem{RichText: c.text, Color: string(c.color)}), c)
+}
+
+// Numbered appends a numbered list item.
+func (b *Builder) Numbered(parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewNumberedListItemBlock(notion.ListItem{RichText: c.text, Color: string(c.color)}), c)
+}
+
+// ToDo appends a to-do item.
+func (b *Builder) ToDo(checked bool, parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewToDoBlock(notion.ToDo{RichText: c.text, Checked: checked, Color: string(c.color)}), c)
+}
+
+// Toggle appends a toggle block. Its children are hidden under the toggle.
+func (b *Builder) Toggle(parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewToggleBlock(notion.Toggle{RichText: c.text, Color: string(c.color)}), c)
+}
+
+// Quote appends a quote.
+func (b *Builder) Quote(parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewQuoteBlock(notion.Quote{RichText: c.text, Color: string(c.color)}), c)
+}
+
+// Callout appends a callout with the given icon (optional).
+func (b *Builder) Callout(icon *notion.Icon, parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewCalloutBlock(notion.Callout{RichText: c.text, Icon: icon, Color: c.color}), c)
+}
+
+// Template appends a template block.
+// Deprecated: Notion doesn't support creating template blocks since March 2023.
+func (b *Builder) Template(parts ...any) *Builder {
+	c := parse(parts)
+	return b.add(notion.NewTemplateBlock(notion.Template{RichText: c.text}), c)
+}
+
+// Code appends a code block in the given language (e.g. "go", "plain text") with an optional caption.
+func (b *Builder) Code(language, code string, caption ...any) *Builder {
+	return b.Add(notion.NewCodeBlock(notion.Code{
+		RichText: notion.RichTexts{notion.NewTextRichText(code)},
+		Caption:  Text(caption...),
+		Language: language,
+	}))
+}
+
+// Equation appends a standalone equation block with the given KaTeX expression.
+func (b *Builder) Equation(expression string) *Builder {
+	return b.Add(notion.NewEquationBlock(expression))
+}
+
+// Divider appends a divider.
+func (b *Builder) Divider() *Builder {
+	return b.Add(notion.NewDividerBlock())
+}
+
+// Breadcrumb appends a breadcrumb.
+func (b *Builder) Breadcrumb() *Builder {
+	return b.Add(notion.NewBreadcrumbBlock())
+}
+
+// TableOfContents appends a table of contents. The color is optional.
+func (b *Builder) TableOfContents(color ...notion.Color) *Builder {
+	var toc notion.TableOfContents
+	if len(color) > 0 {
+		toc.Color = string(color[0])
+	}
+	return b.Add(notion.NewTableOfContentsBlock(toc))
+}
+
+// Bookmark appends a bookmark of the URL with an optional caption.
+func (b *Builder) Bookmark(url string, caption ...any) *Builder {
+	return b.Add(notion.NewBookmarkBlock(notion.Bookmark{URL: url, Caption: Text(caption...)}))
+}
+
+// Embed appends an embed of the URL with an optional caption.
+func (b *Builder) Embed(url string, caption ...any) *Builder {
+	return b.Add(notion.NewEmbedBlock(notion.Embed{URL: url, Caption: Text(caption...)}))
+}
+
+// LinkPreview appends a link preview of the URL.
+// Note that link previews can't be created by the API.
+func (b *Builder) LinkPreview(url string) *Builder {
+	return b.Add(notion.NewLinkPreviewBlock(notion.LinkPreview{URL: url}))
+}
+
+// Image appends an image with the external URL and an optional caption.
+func (b *Builder) Image(url string, caption ...any) *Builder {
+	return b.Add(notion.NewImageBlock(externalFile(url, caption)))
+}
+
+// Video appends a video with the external URL and an optional caption.
+func (b *Builder) Video(url string, caption ...any) *Builder {
+	return b.Add(notion.NewVideoBlock(externalFile(url, caption)))
+}
+
+// Audio appends an audio with the external URL and an optional caption.
+func (b *Builder) Audio(url string, caption ...any) *Builder {
+	return b.Add(notion.NewAudioBlock(externalFile(url, caption)))
+}
+
+// File appends a file with the external URL and an optional caption.
+func (b *Builder) File(url string, caption ...any) *Builder {
+	return b.Add(notion.NewFileBlock(externalFile(url, caption)))
+}
+
+// Pdf appends a PDF with the external URL and an optional caption.
+func (b *Builder) Pdf(url string, caption ...any) *Builder {
+	return b.Add(notion.NewPdfBlock(externalFile(url, caption)))
+}
+
+// LinkToPage appends a link to the page.
+func (b *Builder) LinkToPage(pageID notion.PageID) *Builder {
+	return b.Add(notion.NewLinkToPageBlock(pageID))
+}
+
+// LinkToDatabase appends a link to the database.
+func (b *Builder) LinkToDatabase(databaseID notion.DatabaseID) *Builder {
+	return b.Add(notion.NewLinkToDatabaseBlock(databaseID))
+}
+
+// ChildPage appends a child page block.
+// Note that child pages are created by PagesService.Create, not by appending blocks.
+func (b *Builder) ChildPage(title string) *Builder {
+	return b.Add(notion.NewChildPageBlock(title))
+}
+
+// ChildDatabase appends a child database block.
+// Note that child databases are created by DatabasesService.Create, not by appending blocks.
+func (b *Builder) ChildDatabase(title string) *Builder {
+	return b.Add(notion.NewChildDataBasicBlock(title))
+}
+
+// Unsupported appends a block of unsupported type. It's only useful for tests.
+func (b *Builder) Unsupported() *Builder {
+	return b.Add(notion.NewUnsupportedBlock())
+}
+
+// Columns appends a column list with a column for each of the given functions building their content.
+// Notion requires at least 2 columns.
+func (b *Builder) Columns(columns ...func(*Builder)) *Builder {
+	list := make(notion.Blocks, len(columns))
+	for i, column := range columns {
+		list[i] = notion.NewColumnBlock(notion.Column{AtomChildren: notion.AtomChildren{Children: buildBlocks(column)}})
+	}
+	return b.Add(notion.NewColumnListBlock(notion.ColumnList{AtomChildren: notion.AtomChildren{Children: list}}))
+}
+
+// Synced appends an original synced block with the given content.
+func (b *Builder) Synced(children ...any) *Builder {
+	return b.add(notion.NewSyncedBlock(notion.Synced{}), parse(children))
+}
+
+// SyncedFrom appends a reference to the original synced block.
+func (b *Builder) SyncedFrom(blockID notion.BlockID) *Builder {
+	return b.Add(notion.NewSyncedBlock(notion.Synced{SyncedFrom: &notion.SyncedFrom{BlockID: blockID}}))
+}
+
+// Table appends a table with the given rows. Each cell is a list of parts of its text (see Text),
+// or a single part; nil cells are empty. The width of the table is the length of the longest row:
+// shorter rows get empty cells. Tables without cells can't be created, so they are skipped.
+func (b *Builder) Table(rows [][]any) *Builder {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return b
+	}
+
+	tableRows := make(notion.Blocks, len(rows))
+	for i, row := range rows {
+		cells := make([]notion.RichTexts, width)
+		for j, cell := range row {
+			if parts, ok := cell.([]any); ok {
+				cells[j] = Text(parts...)
+			} else {
+				cells[j] = Text(cell)
+			}
+		}
+		for j, cell := range cells {
+			if cell == nil {
+				cells[j] = notion.RichTexts{}
+			}
+		}
+		tableRows[i] = notion.NewTableRowBlock(notion.TableRow{Cells: cells})
+	}
+
+	return b.Add(notion.NewTableBlock(notion.Table{
+		AtomChildren: notion.AtomChildren{Children: tableRows},
+		TableWidth:   width,
+	}))
+}
+
+// add appends the block with the children of the content.
+func (b *Builder) add(block notion.Block, c content) *Builder {
+	if len(c.children) > 0 {
+		// SetChildren of the blocks updates HasChildren
+		h := block.(notion.HierarchicalBlock)
+		h.SetChildren(append(h.GetChildren(), c.children...))
+	}
+
+	b.blocks = append(b.blocks, block)
+	return b
+}
+
+// content is the parsed list of parts of a block.
+type content struct {
+	text     notion.RichTexts
+	color    notion.Color
+	children notion.Blocks
+}
+
+// parse splits the parts into the text, color and children of a block.
+func parse(parts []any) content {
+	var c content
+	for _, part := range parts {
+		switch p := part.(type) {
+		case notion.Color:
+			c.color = p
+		case func(*Builder):
+			c.children = append(c.children, buildBlocks(p)...)
+		case notion.Blocks:
+			c.children = append(c.children, p...)
+		case notion.Block:
+			c.children = append(c.children, p)
+		default:
+			c.text = append(c.text, Text(p)...)
+		}
+	}
+	if c.text == nil {
+		c.text = notion.RichTexts{}
+	}
+	return c
+}
+
+// buildBlocks returns the blocks built by the function.
+func buildBlocks(f func(*Builder)) notion.Blocks {
+	b := Doc()
+	if f != nil {
+		f(b)
+	}
+	return b.Blocks()
+}
+
+// externalFile returns an external file with the URL and caption.
+func externalFile(url string, caption []any) notion.File {
+	return notion.File{
+		Type:     notion.FileTypeExternal,
+		External: &notion.FileData{URL: url},
+		Caption:  Text(caption...),
+	}
+}
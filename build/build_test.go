@@ -0,0 +1,167 @@
+package build_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/build"
+)
+
+func TestBuilder(t *testing.T) {
+	blocks := build.Doc().
+		H1("Title").
+		H2("Subtitle", notion.ColorBlue).
+		H3("Section").
+		ToggleHeading(1, "Hidden", func(b *build.Builder) { b.P("inside") }).
+		P("Some ", build.Bold("bold"), " text").
+		Bullet("first", func(b *build.Builder) { b.Numbered("nested") }).
+		ToDo(true, "done").
+		Toggle("More", func(b *build.Builder) {
+			b.Quote("quote").Callout(notion.NewEmojiIcon("💡"), "note")
+		}).
+		Code("go", "fmt.Println()", "caption").
+		Equation("e=mc^2").
+		Divider().
+		Breadcrumb().
+		TableOfContents().
+		Bookmark("https://example.com").
+		Embed("https://example.com/embed").
+		LinkPreview("https://example.com/preview").
+		Image("https://example.com/image.png").
+		Video("https://example.com/video.mp4").
+		Audio("https://example.com/audio.mp3").
+		File("https://example.com/file.zip").
+		Pdf("https://example.com/file.pdf").
+		LinkToPage("page").
+		LinkToDatabase("database").
+		ChildPage("Child page").
+		ChildDatabase("Child database").
+		Columns(
+			func(b *build.Builder) { b.P("left") },
+			func(b *build.Builder) { b.P("right") },
+		).
+		Synced(func(b *build.Builder) { b.P("synced") }).
+		SyncedFrom("original").
+		Table([][]any{{"Name", "Value"}, {"a", 1, build.Italic("extra")}}).
+		Template("template").
+		Unsupported().
+		Blocks()
+
+	t.Run("should cover every registered block type", func(t *testing.T) {
+		var types []notion.BlockType
+		var walk func(notion.Blocks)
+		walk = func(blocks notion.Blocks) {
+			for _, block := range blocks {
+				if !slices.Contains(types, block.GetType()) {
+					types = append(types, block.GetType())
+				}
+				walk(block.(notion.HierarchicalBlock).GetChildren())
+			}
+		}
+		walk(blocks)
+		slices.Sort(types)
+
+		assert.Equal(t, notion.RegisteredBlockTypes(), types)
+	})
+
+	t.Run("should set HasChildren", func(t *testing.T) {
+		var walk func(notion.Blocks)
+		walk = func(blocks notion.Blocks) {
+			for _, block := range blocks {
+				children := block.(notion.HierarchicalBlock).GetChildren()
+				assert.Equal(t, len(children) > 0, block.GetHasChildren(), block.GetType())
+				walk(children)
+			}
+		}
+		walk(blocks)
+	})
+
+	t.Run("should decode to the same blocks", func(t *testing.T) {
+		data, err := json.Marshal(blocks)
+		require.NoError(t, err)
+
+		var decoded notion.Blocks
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Len(t, decoded, len(blocks))
+		for i, block := range blocks {
+			assert.IsType(t, block, decoded[i])
+			assert.True(t, notion.EqualContent(block, decoded[i]), block.GetType())
+		}
+	})
+
+	t.Run("should build the content", func(t *testing.T) {
+		heading := blocks[1].(*notion.Heading2Block).Heading2
+		assert.Equal(t, notion.ColorBlue, heading.Color)
+		assert.Equal(t, "Subtitle", heading.RichText[0].PlainText)
+		assert.True(t, blocks[3].(*notion.Heading1Block).Heading1.IsToggleable)
+
+		paragraph := blocks[4].(*notion.ParagraphBlock).Paragraph
+		require.Len(t, paragraph.RichText, 3)
+		assert.True(t, paragraph.RichText[1].Annotations.Bold)
+		assert.False(t, paragraph.RichText[2].Annotations.Bold)
+
+		table := blocks[len(blocks)-3].(*notion.TableBlock)
+		assert.Equal(t, 3, table.Table.TableWidth)
+		rows := table.GetChildren()
+		require.Len(t, rows, 2)
+		assert.Equal(t, []notion.RichTexts{
+			{notion.NewTextRichText("Name")}, {notion.NewTextRichText("Value")}, {},
+		}, rows[0].(*notion.TableRowBlock).TableRow.Cells)
+		assert.Equal(t, "1", rows[1].(*notion.TableRowBlock).TableRow.Cells[1][0].PlainText)
+	})
+}
+
+func TestBuilder_Table(t *testing.T) {
+	t.Run("should make nil cells empty", func(t *testing.T) {
+		blocks := build.Doc().Table([][]any{{nil, "a"}, {[]any{}}}).Blocks()
+		require.Len(t, blocks, 1)
+
+		table := blocks[0].(*notion.TableBlock)
+		assert.Equal(t, 2, table.Table.TableWidth)
+		rows, err := table.Strings()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"", "a"}, {"", ""}}, rows)
+
+		data, err := json.Marshal(table)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"cells":[[],[]]`)
+	})
+
+	t.Run("should skip tables without cells", func(t *testing.T) {
+		assert.Empty(t, build.Doc().Table(nil).Table([][]any{{}, {}}).Blocks())
+	})
+}
+
+func TestText(t *testing.T) {
+	text := build.Text(
+		"plain ",
+		build.Link("link", "https://example.com"),
+		build.InlineCode("code"),
+		build.Colored(notion.ColorRedBackground, "red"),
+		build.InlineEquation("x^2"),
+		build.MentionPage("page"),
+		build.MentionDatabase("database"),
+		build.MentionUser("user"),
+		notion.RichTexts{build.Strikethrough("s"), build.Underline("u")},
+	)
+	require.Len(t, text, 10)
+
+	assert.Equal(t, "https://example.com", text[1].Text.Link.URL)
+	assert.True(t, text[2].Annotations.Code)
+	assert.Equal(t, notion.ColorRedBackground, text[3].Annotations.Color)
+	assert.Equal(t, notion.RichTextTypeEquation, text[4].Type)
+	for _, mention := range text[5:8] {
+		assert.Equal(t, notion.RichTextTypeMention, mention.Type)
+	}
+	assert.True(t, text[8].Annotations.Strikethrough)
+	assert.True(t, text[9].Annotations.Underline)
+
+	assert.PanicsWithValue(t, "build: unsupported text part struct {}", func() {
+		build.Text(struct{}{})
+	})
+}
@@ -0,0 +1,81 @@
+package build
+
+import (
+	"fmt"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// Text joins the parts into rich texts. The parts can be:
+//   - strings and other scalars (numbers, booleans, fmt.Stringer) for plain text;
+//   - notion.RichText, *notion.RichText and notion.RichTexts (e.g. made by Bold, Link or MentionPage).
+//
+// Nil parts are skipped, other parts panic.
+func Text(parts ...any) notion.RichTexts {
+	var result notion.RichTexts
+	for _, part := range parts {
+		switch p := part.(type) {
+		case nil:
+		case string:
+			result = append(result, notion.NewTextRichText(p))
+		case notion.RichText:
+			result = append(result, p)
+		case *notion.RichText:
+			result = append(result, *p)
+		case notion.RichTexts:
+			result = append(result, p...)
+		case fmt.Stringer:
+			result = append(result, notion.NewTextRichText(p.String()))
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			result = append(result, notion.NewTextRichText(fmt.Sprint(p)))
+		default:
+			panic(fmt.Sprintf("build: unsupported text part %T", part))
+		}
+	}
+	return result
+}
+
+// Bold returns the bold text.
+func Bold(text string) notion.RichText { return notion.NewTextRichText(text).WithBold() }
+
+// Italic returns the italic text.
+func Italic(text string) notion.RichText { return notion.NewTextRichText(text).WithItalic() }
+
+// Strikethrough returns the strikethrough text.
+func Strikethrough(text string) notion.RichText {
+	return notion.NewTextRichText(text).WithStrikethrough()
+}
+
+// Underline returns the underlined text.
+func Underline(text string) notion.RichText { return notion.NewTextRichText(text).WithUnderline() }
+
+// InlineCode returns the text formatted as code.
+func InlineCode(text string) notion.RichText { return notion.NewTextRichText(text).WithCode() }
+
+// Colored returns the text of the given color (or background color, e.g. notion.ColorRedBackground).
+func Colored(color notion.Color, text string) notion.RichText {
+	return notion.NewTextRichText(text).WithColor(color)
+}
+
+// Link returns the text linked to the URL.
+func Link(text, url string) notion.RichText { return notion.NewLinkRichText(text, url) }
+
+// InlineEquation returns the inline equation with the given KaTeX expression.
+func InlineEquation(expression string) notion.RichText {
+	return *notion.NewEquationRichText(expression)
+}
+
+// MentionPage returns the mention of the page.
+func MentionPage(pageID notion.PageID) notion.RichText {
+	return *notion.NewPageMentionRichText(pageID)
+}
+
+// MentionDatabase returns the mention of the database.
+func MentionDatabase(databaseID notion.DatabaseID) notion.RichText {
+	return *notion.NewDatabaseMentionRichText(databaseID)
+}
+
+// MentionUser returns the mention of the user.
+func MentionUser(userID notion.UserID) notion.RichText {
+	return *notion.NewUserMentionRichText(userID)
+}
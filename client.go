@@ -44,6 +44,10 @@ func New(token Token, opts ...ClientOpt) *Client {
 	return c
 }
 
+// RateLimitStats returns the statistics of the client-side rate limiter.
+// It returns zero stats if rate limiting is not enabled.
+func (c *Client) RateLimitStats() RateLimitStats { return c.api.rateLimiter.Stats() }
+
 // ClientOpt to configure API client
 type ClientOpt func(*Client)
 
@@ -52,14 +56,44 @@ func WithTransport(transport http.RoundTripper) ClientOpt {
 	return func(c *Client) { c.api.transport = transport }
 }
 
+// WithBaseURL overrides the default Notion API URL (https://api.notion.com),
+// e.g. to use a proxy or a local fake Notion server. The path of the URL (if any)
+// is kept as a prefix of all endpoints: "http://localhost:8080/notion" results in
+// "http://localhost:8080/notion/v1/pages/...". An invalid URL makes every request fail.
+func WithBaseURL(baseURL string) ClientOpt {
+	return func(c *Client) { c.api.configErr = c.api.setBaseURL(baseURL) }
+}
+
+// WithPathPrefix sets the path prefix added between the base URL and the API version,
+// e.g. "/notion" for a proxy serving Notion API under a sub-path.
+func WithPathPrefix(prefix string) ClientOpt {
+	return func(c *Client) { c.api.pathPrefix = prefix }
+}
+
 // WithVersion overrides the Notion API version
 func WithVersion(version string) ClientOpt {
 	return func(c *Client) { c.api.notionVersion = version }
 }
 
-// WithRetry overrides the default number of max retry attempts on 429 errors
+// WithRetry overrides the default number of max retry attempts
 func WithRetry(retries int) ClientOpt {
-	return func(c *Client) { c.api.maxRetries = retries }
+	return func(c *Client) { c.api.retryPolicy.MaxAttempts = retries }
+}
+
+// WithRetryPolicy overrides the default RetryPolicy
+func WithRetryPolicy(policy RetryPolicy) ClientOpt {
+	return func(c *Client) { c.api.retryPolicy = policy }
+}
+
+// WithRateLimit enables the client-side rate limiter shared by all services.
+// Use DefaultRequestsPerSecond to stay under the Notion's average limit.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOpt {
+	return func(c *Client) { c.api.rateLimiter = NewRateLimiter(requestsPerSecond, burst) }
+}
+
+// WithRateLimiter sets the given RateLimiter, so it can be shared between several clients.
+func WithRateLimiter(limiter *RateLimiter) ClientOpt {
+	return func(c *Client) { c.api.rateLimiter = limiter }
 }
 
 // WithOAuthAppCredentials sets the OAuth app ID and secret to use when fetching a token from Notion.
@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
-	"strconv"
+	"strings"
 	"time"
 )
 
@@ -35,10 +37,20 @@ type clientAPI struct {
 
 	transport     http.RoundTripper
 	parsedBaseURL *url.URL
+	pathPrefix    string
 
-	maxRetries int
+	// configErr is an error of the client configuration (e.g. invalid base URL).
+	// It's returned by every request.
+	configErr error
 
-	errDecoder errJSONDecodeFunc
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+
+	errDecoder    errJSONDecodeFunc
+	middlewares   []Middleware
+	responseHooks []ResponseHook
+
+	logger *slog.Logger
 
 	// used in Authorization header only for requests that require Basic authentication.
 	oauthID     string
@@ -47,18 +59,12 @@ type clientAPI struct {
 
 // newClientAPI creates a new API Client. It's used internally, as a wrapper on HTTP mechanics.
 func newClientAPI(token Token) *clientAPI {
-	u, err := url.Parse(apiURL)
-	if err != nil {
-		panic(err)
-	}
-
-	return &clientAPI{
+	c := &clientAPI{
 		token:         token,
 		transport:     http.DefaultTransport,
-		parsedBaseURL: u,
 		apiVersion:    apiVersion,
 		notionVersion: currentNotionVersion,
-		maxRetries:    maxRetries,
+		retryPolicy:   DefaultRetryPolicy(),
 
 		errDecoder: func(data []byte) error {
 			var apiErr APIError
@@ -69,25 +75,43 @@ func newClientAPI(token Token) *clientAPI {
 			return &apiErr
 		},
 	}
-}
+	if err := c.setBaseURL(apiURL); err != nil {
+		panic(err)
+	}
 
-func (c *clientAPI) request(ctx context.Context, method string, path string, params map[string]string, payload any) (*http.Response, error) {
-	return c.requestRaw(ctx, method, path, params, payload, false, c.errDecoder)
+	return c
 }
 
-func (c *clientAPI) requestRaw(ctx context.Context, method string, path string, params map[string]string, payload any, basicAuth bool, customErrDecoder errJSONDecodeFunc) (*http.Response, error) {
-	u, err := c.parsedBaseURL.Parse(fmt.Sprintf("%s/%s", c.apiVersion, path))
+// requestRaw makes the HTTP request, retrying it according to the retry policy.
+// Details of the response are recorded into meta (if not nil).
+func (c *clientAPI) requestRaw(ctx context.Context, method string, path string, params map[string]string, payload any, basicAuth bool, customErrDecoder errJSONDecodeFunc, meta *ResponseInfo) (*http.Response, error) {
+	if meta == nil {
+		meta = &ResponseInfo{}
+	}
+	started := time.Now()
+	defer func() { meta.Duration = time.Since(started) }()
+
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+
+	// path is relative, so it's resolved against the base URL including its path (if any)
+	rel := fmt.Sprintf("%s/%s", c.apiVersion, path)
+	if prefix := strings.Trim(c.pathPrefix, "/"); prefix != "" {
+		rel = prefix + "/" + rel
+	}
+	u, err := c.parsedBaseURL.Parse(rel)
 	if err != nil {
 		return nil, err
 	}
 
-	var buf io.ReadWriter
+	// body is marshalled once and re-read on every attempt,
+	// because a request body can be consumed only once.
+	var body []byte
 	if payload != nil && !reflect.ValueOf(payload).IsNil() {
-		body, err := json.Marshal(payload)
-		if err != nil {
+		if body, err = json.Marshal(payload); err != nil {
 			return nil, err
 		}
-		buf = bytes.NewBuffer(body)
 	}
 
 	if len(params) > 0 {
@@ -97,7 +121,132 @@ func (c *clientAPI) requestRaw(ctx context.Context, method string, path string,
 		}
 		u.RawQuery = q.Encode()
 	}
-	req, err := http.NewRequest(method, u.String(), buf)
+
+	meta.Method, meta.Path = method, u.Path
+
+	policy := c.retryPolicy
+	var totalWait time.Duration
+	for attempt := 1; ; attempt++ {
+		rateLimitWait, err := c.rateLimiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := c.newRequest(ctx, method, u.String(), body, basicAuth)
+		if err != nil {
+			return nil, err
+		}
+
+		meta.Attempts = attempt
+		info := attemptInfo{method: method, path: u.Path, attempt: attempt, rateLimitWait: rateLimitWait}
+		start := time.Now()
+		res, err := c.transport.RoundTrip(req)
+		info.duration = time.Since(start)
+		if err != nil {
+			info.err = err
+			if attempt >= policy.attempts() || !policy.isRetryableError(method, err) {
+				c.logAttempt(ctx, info)
+				return nil, err
+			}
+			wait := policy.backoff(attempt)
+			if policy.MaxTotalWait > 0 && totalWait+wait > policy.MaxTotalWait {
+				c.logAttempt(ctx, info)
+				return nil, err
+			}
+			info.retrying, info.retryIn = true, wait
+			c.logAttempt(ctx, info)
+
+			totalWait += wait
+			if errSleep := sleepContext(ctx, wait); errSleep != nil {
+				return nil, errSleep
+			}
+			continue
+		}
+
+		info.status = res.StatusCode
+		info.requestID = res.Header.Get(headerRequestID)
+		meta.StatusCode, meta.Header, meta.RequestID = res.StatusCode, res.Header, info.requestID
+
+		if res.StatusCode == http.StatusOK {
+			c.logAttempt(ctx, info)
+			return res, nil
+		}
+
+		if !policy.isRetryableStatus(method, res.StatusCode) {
+			c.logAttempt(ctx, info)
+			return nil, c.decodeErrorResponse(res, customErrDecoder, meta)
+		}
+
+		// https://developers.notion.com/reference/request-limits#rate-limits
+		wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			wait = policy.backoff(attempt)
+		}
+
+		exhausted := attempt >= policy.attempts()
+		overBudget := policy.MaxTotalWait > 0 && totalWait+wait > policy.MaxTotalWait
+		if exhausted || overBudget {
+			c.logAttempt(ctx, info)
+			if res.StatusCode == http.StatusTooManyRequests {
+				msg := fmt.Sprintf("Retry request with 429 response failed after %d retries", attempt)
+				if !exhausted {
+					msg = fmt.Sprintf("Retry request with 429 response exceeded max total wait of %s after %d retries", policy.MaxTotalWait, attempt)
+				}
+				rateLimitedErr := &RateLimitedError{
+					Message:    msg,
+					Attempts:   attempt,
+					RetryAfter: wait,
+					RequestID:  info.requestID,
+					Method:     method,
+					Path:       u.Path,
+				}
+				errors.As(c.decodeErrorResponse(res, c.errDecoder, meta), &rateLimitedErr.Err)
+				return nil, rateLimitedErr
+			}
+			return nil, c.decodeErrorResponse(res, customErrDecoder, meta)
+		}
+
+		info.retrying, info.retryIn = true, wait
+		c.logAttempt(ctx, info)
+
+		// response is dropped: it will be retried
+		c.closeBody(res)
+
+		totalWait += wait
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// setBaseURL parses and sets the base URL. Its path (if any) is kept as a prefix of all endpoints.
+func (c *clientAPI) setBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid base URL %q: scheme and host are required", baseURL)
+	}
+
+	// trailing slash makes relative endpoint paths resolve under the base path
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawQuery, u.Fragment = "", ""
+
+	c.parsedBaseURL = u
+	return nil
+}
+
+// newRequest builds a single attempt of the HTTP request.
+func (c *clientAPI) newRequest(ctx context.Context, method string, rawURL string, body []byte, basicAuth bool) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
 	if err != nil {
 		return nil, err
 	}
@@ -111,48 +260,65 @@ func (c *clientAPI) requestRaw(ctx context.Context, method string, path string,
 	req.Header.Add("Notion-Version", c.notionVersion)
 	req.Header.Add("Content-Type", "application/json")
 
-	failedAttempts := 0
-	var res *http.Response
-	for {
-		var err error
-		res, err = c.transport.RoundTrip(req.WithContext(ctx))
-		if err != nil {
-			return nil, err
-		}
+	return req, nil
+}
 
-		if res.StatusCode != http.StatusTooManyRequests {
-			break
-		}
+// decodeErrorResponse reads the failed response and decodes it into an error.
+// Decoded *APIError is annotated with the details of the request and response.
+// Responses without a JSON body (e.g. errors produced by proxies) are turned into *APIError
+// with a code guessed from the HTTP status.
+func (c *clientAPI) decodeErrorResponse(res *http.Response, decoder errJSONDecodeFunc, meta *ResponseInfo) error {
+	defer c.closeBody(res)
 
-		failedAttempts++
-		if failedAttempts == c.maxRetries {
-			return nil, &RateLimitedError{Message: fmt.Sprintf("Retry request with 429 response failed after %d retries", failedAttempts)}
-		}
-		// https://developers.notion.com/reference/request-limits#rate-limits
-		retryAfterHeader := res.Header["Retry-After"]
-		if len(retryAfterHeader) == 0 {
-			return nil, &RateLimitedError{Message: "Retry-After header missing from Notion API response headers for 429 response"}
+	var data []byte
+	if res.Body != nil {
+		var err error
+		if data, err = io.ReadAll(res.Body); err != nil {
+			return err
 		}
-		retryAfter := retryAfterHeader[0]
+	}
+	meta.Body = data
 
-		waitSeconds, err := strconv.Atoi(retryAfter)
-		if err != nil {
-			break // should not happen
+	var err error
+	if json.Valid(data) {
+		err = decoder(data)
+	} else {
+		message := http.StatusText(res.StatusCode)
+		if text := strings.TrimSpace(string(data)); text != "" {
+			message = text
 		}
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(time.Duration(waitSeconds) * time.Second):
+		err = &APIError{
+			Object:  ObjectTypeError,
+			Status:  res.StatusCode,
+			Code:    errorCodeForStatus(res.StatusCode),
+			Message: message,
 		}
 	}
 
-	if res.StatusCode != http.StatusOK {
-		data, err := io.ReadAll(res.Body)
-		if err != nil {
-			return nil, err
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Status == 0 {
+			apiErr.Status = res.StatusCode
+		}
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = res.Header.Get(headerRequestID)
+		}
+		apiErr.Method, apiErr.Path = meta.Method, meta.Path
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			apiErr.RetryAfter = retryAfter
 		}
-		return nil, customErrDecoder(data)
 	}
 
-	return res, nil
+	return err
+}
+
+// closeBody drains and closes the response body (if any).
+func (c *clientAPI) closeBody(res *http.Response) {
+	if res == nil || res.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	if errClose := res.Body.Close(); errClose != nil && c.logger != nil {
+		c.logger.Error("failed to close response body", slog.Any("error", errClose))
+	}
 }
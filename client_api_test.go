@@ -2,11 +2,15 @@ package notion_test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -78,6 +82,295 @@ func TestRateLimit(t *testing.T) {
 	})
 }
 
+// mockedResponse returns *http.Response with content from a given file.
+func mockedResponse(t *testing.T, requestMockFile string, statusCode int) *http.Response {
+	res, err := newMockedClient(t, requestMockFile, statusCode).RoundTrip(nil)
+	require.NoError(t, err)
+	return res
+}
+
+// fastRetryPolicy is a RetryPolicy that does not make tests slow.
+func fastRetryPolicy(attempts int) notion.RetryPolicy {
+	policy := notion.DefaultRetryPolicy()
+	policy.MaxAttempts = attempts
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = time.Millisecond
+	return policy
+}
+
+// transportFunc is a RoundTripper that can fail with an error.
+type transportFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip executes the custom RoundTrip function.
+func (f transportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("should retry 5xx responses and rebuild the request body", func(t *testing.T) {
+		var bodies []string
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(body))
+
+			if len(bodies) < 3 {
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Body:       io.NopCloser(strings.NewReader(`{"object":"error","status":502,"code":"bad_gateway","message":"bad gateway"}`)),
+					Header:     make(http.Header),
+				}
+			}
+
+			return mockedResponse(t, "testdata/block_update.json", http.StatusOK)
+		})
+		policy := fastRetryPolicy(3)
+		policy.RetryNonIdempotent = true
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(policy),
+		)
+
+		_, err := client.Blocks.Update(context.Background(), "some_id", &notion.BlockUpdateRequest{
+			Paragraph: &notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText("Hello")}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, bodies, 3)
+		assert.NotEmpty(t, bodies[0])
+		assert.Equal(t, bodies[0], bodies[1], "retried request must send the same body")
+		assert.Equal(t, bodies[0], bodies[2], "retried request must send the same body")
+	})
+
+	t.Run("should return the API error when 5xx retries are exhausted", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			attempts++
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       io.NopCloser(strings.NewReader(`{"object":"error","status":503,"code":"service_unavailable","message":"unavailable"}`)),
+				Header:     make(http.Header),
+			}
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(2)),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		var apiErr *notion.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("should not retry non-retryable statuses", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			attempts++
+			return mockedResponse(t, "testdata/validation_error.json", http.StatusBadRequest)
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("should accept Retry-After as HTTP-date", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}},
+				}
+			}
+			return mockedResponse(t, "testdata/block_get.json", http.StatusOK)
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("should retry on missing Retry-After header", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Header: make(http.Header)}
+			}
+			return mockedResponse(t, "testdata/block_get.json", http.StatusOK)
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("should stop when max total wait is exceeded", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			attempts++
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"60"}},
+			}
+		})
+		policy := fastRetryPolicy(5)
+		policy.MaxTotalWait = time.Second
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(policy),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		var rateLimitedErr *notion.RateLimitedError
+		assert.ErrorAs(t, err, &rateLimitedErr)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("should retry temporary network errors", func(t *testing.T) {
+		attempts := 0
+		transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts < 3 {
+				return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: timeoutError{}}
+			}
+			return mockedResponse(t, "testdata/block_get.json", http.StatusOK), nil
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("should not retry network errors of non-idempotent requests unless enabled", func(t *testing.T) {
+		attempts := 0
+		transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts < 3 {
+				return nil, &url.Error{Op: "Patch", URL: req.URL.String(), Err: io.ErrUnexpectedEOF}
+			}
+			return mockedResponse(t, "testdata/block_append_children.json", http.StatusOK), nil
+		})
+		request := &notion.AppendBlockChildrenRequest{Children: notion.Blocks{notion.NewParagraphBlock(notion.Paragraph{})}}
+
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+		_, err := client.Blocks.AppendChildren(context.Background(), "some_block_id", request)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, 1, attempts)
+
+		attempts = 0
+		policy := fastRetryPolicy(3)
+		policy.RetryNonIdempotent = true
+		client = notion.New("some_token", notion.WithTransport(transport), notion.WithRetryPolicy(policy))
+		_, err = client.Blocks.AppendChildren(context.Background(), "some_block_id", request)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("should not retry server errors of non-idempotent requests", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Body:       io.NopCloser(strings.NewReader(`{"object":"error","status":502,"code":"bad_gateway","message":"bad gateway"}`)),
+					Header:     make(http.Header),
+				}
+			}
+			return mockedResponse(t, "testdata/block_append_children.json", http.StatusOK)
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.AppendChildren(context.Background(), "some_block_id", &notion.AppendBlockChildrenRequest{
+			Children: notion.Blocks{notion.NewParagraphBlock(notion.Paragraph{})},
+		})
+
+		var apiErr *notion.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.Status)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("should retry rate limited non-idempotent requests", func(t *testing.T) {
+		attempts := 0
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Header: make(http.Header)}
+			}
+			return mockedResponse(t, "testdata/block_append_children.json", http.StatusOK)
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.AppendChildren(context.Background(), "some_block_id", &notion.AppendBlockChildrenRequest{
+			Children: notion.Blocks{notion.NewParagraphBlock(notion.Paragraph{})},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("should not retry permanent network errors", func(t *testing.T) {
+		attempts := 0
+		errPermanent := errors.New("permanent failure")
+		transport := transportFunc(func(*http.Request) (*http.Response, error) {
+			attempts++
+			return nil, errPermanent
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(fastRetryPolicy(3)),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_block_id")
+
+		assert.ErrorIs(t, err, errPermanent)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
 func TestBasicAuthHeader(t *testing.T) {
 	t.Parallel()
 
@@ -107,3 +400,53 @@ func TestBasicAuthHeader(t *testing.T) {
 	_, err = client.Auth.CreateToken(context.Background(), &notion.TokenCreateRequest{})
 	assert.NoError(t, err, "unexpected error during token creation")
 }
+
+func TestWithBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		paths = append(paths, request.URL.Path)
+
+		file := "testdata/user_me.json"
+		if strings.HasSuffix(request.URL.Path, "/oauth/token") {
+			file = "testdata/create_token.json"
+		}
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		_, _ = writer.Write(data)
+	}))
+	defer srv.Close()
+
+	t.Run("should send all requests to the base URL", func(t *testing.T) {
+		paths = nil
+		client := notion.New("some_token", notion.WithBaseURL(srv.URL))
+
+		_, err := client.Users.Me(context.Background())
+		require.NoError(t, err)
+		_, err = client.Auth.CreateToken(context.Background(), &notion.TokenCreateRequest{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/v1/users/me", "/v1/oauth/token"}, paths)
+	})
+
+	t.Run("should keep the path of the base URL and the path prefix", func(t *testing.T) {
+		paths = nil
+		client := notion.New("some_token",
+			notion.WithBaseURL(srv.URL+"/proxy"),
+			notion.WithPathPrefix("/notion/"),
+		)
+
+		_, err := client.Users.Me(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/proxy/notion/v1/users/me"}, paths)
+	})
+
+	t.Run("should fail on invalid base URL", func(t *testing.T) {
+		client := notion.New("some_token", notion.WithBaseURL("localhost"))
+
+		_, err := client.Users.Me(context.Background())
+		assert.Error(t, err)
+	})
+}
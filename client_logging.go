@@ -0,0 +1,85 @@
+package notion
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// headerRequestID is the response header holding the Notion's request ID.
+const headerRequestID = "X-Request-Id"
+
+// WithLogger sets the structured logger used by the client.
+// Every attempt of every request is logged: successful ones on Debug level,
+// failed and retried ones on Warn level. Tokens and credentials are never logged.
+func WithLogger(logger *slog.Logger) ClientOpt {
+	return func(c *Client) { c.api.logger = logger }
+}
+
+// LogValue implements slog.LogValuer, so tokens are redacted when logged.
+func (t Token) LogValue() slog.Value { return slog.StringValue(redactToken(t)) }
+
+// redactToken keeps only the last 4 characters of a long enough token.
+func redactToken(t Token) string {
+	const visible = 4
+	if len(t) <= visible*4 {
+		return "[REDACTED]"
+	}
+	return "[REDACTED]..." + string(t[len(t)-visible:])
+}
+
+// attemptInfo holds details of a single attempt of an HTTP request.
+type attemptInfo struct {
+	method        string
+	path          string
+	attempt       int
+	status        int
+	requestID     string
+	duration      time.Duration
+	rateLimitWait time.Duration
+	retrying      bool
+	retryIn       time.Duration
+	err           error
+}
+
+// logAttempt emits a structured record for the given attempt.
+func (c *clientAPI) logAttempt(ctx context.Context, info attemptInfo) {
+	if c.logger == nil {
+		return
+	}
+
+	level := slog.LevelDebug
+	if info.err != nil || info.status != 0 && (info.status < 200 || info.status > 299) {
+		level = slog.LevelWarn
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", info.method),
+		slog.String("path", info.path),
+		slog.Int("attempt", info.attempt),
+		slog.Duration("duration", info.duration),
+	}
+	if info.status != 0 {
+		attrs = append(attrs, slog.Int("status", info.status))
+	}
+	if info.requestID != "" {
+		attrs = append(attrs, slog.String("request_id", info.requestID))
+	}
+	if info.rateLimitWait > 0 {
+		attrs = append(attrs, slog.Duration("rate_limit_wait", info.rateLimitWait))
+	}
+	if info.err != nil {
+		attrs = append(attrs, slog.Any("error", info.err))
+	}
+
+	msg := "notion request"
+	if info.retrying {
+		attrs = append(attrs, slog.Duration("retry_in", info.retryIn))
+		msg = "notion request failed, retrying"
+	}
+
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
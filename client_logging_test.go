@@ -0,0 +1,92 @@
+package notion_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+const secretToken notion.Token = "secret_0123456789abcdefghijklmnopqrstuvwxyz"
+
+// decodeLogRecords parses JSON log lines.
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Run("should log each attempt", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		attempts := 0
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{"0"}, "X-Request-Id": []string{"req-1"}},
+				}
+			}
+			res := mockedResponse(t, "testdata/block_get.json", http.StatusOK)
+			res.Header.Set("X-Request-Id", "req-2")
+			return res
+		})
+		client := notion.New(secretToken,
+			notion.WithTransport(transport),
+			notion.WithLogger(logger),
+			notion.WithRateLimit(1000, 10),
+		)
+
+		_, err := client.Blocks.Get(context.Background(), "some_id")
+		require.NoError(t, err)
+
+		records := decodeLogRecords(t, &buf)
+		require.Len(t, records, 2)
+
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, "notion request failed, retrying", records[0]["msg"])
+		assert.EqualValues(t, http.StatusTooManyRequests, records[0]["status"])
+		assert.EqualValues(t, 1, records[0]["attempt"])
+		assert.Equal(t, "req-1", records[0]["request_id"])
+		assert.Contains(t, records[0], "retry_in")
+
+		assert.Equal(t, "DEBUG", records[1]["level"])
+		assert.Equal(t, "notion request", records[1]["msg"])
+		assert.Equal(t, http.MethodGet, records[1]["method"])
+		assert.Equal(t, "/v1/blocks/some_id", records[1]["path"])
+		assert.EqualValues(t, http.StatusOK, records[1]["status"])
+		assert.EqualValues(t, 2, records[1]["attempt"])
+		assert.Equal(t, "req-2", records[1]["request_id"])
+		assert.Contains(t, records[1], "duration")
+
+		assert.NotContains(t, buf.String(), secretToken.String())
+	})
+
+	t.Run("should redact tokens", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+		logger.Info("token", slog.Any("token", secretToken))
+
+		assert.NotContains(t, buf.String(), secretToken.String())
+		assert.Contains(t, buf.String(), "[REDACTED]...wxyz")
+	})
+}
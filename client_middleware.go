@@ -0,0 +1,133 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ServiceName is a name of the service (group of Notion API endpoints) an Operation belongs to.
+type ServiceName string
+
+// String returns the string representation of the ServiceName.
+func (s ServiceName) String() string { return string(s) }
+
+// nolint:revive
+const (
+	ServiceAuthentication ServiceName = "authentication"
+	ServiceBlocks         ServiceName = "blocks"
+	ServicePages          ServiceName = "pages"
+	ServiceDatabases      ServiceName = "databases"
+	ServiceUsers          ServiceName = "users"
+	ServiceComments       ServiceName = "comments"
+	ServiceSearch         ServiceName = "search"
+)
+
+// Operation describes a single logical call to the Notion API,
+// e.g. Blocks.AppendChildren for the given block ID.
+type Operation struct {
+	// Service is the service the call belongs to (e.g. ServiceBlocks).
+	Service ServiceName
+	// Method is the name of the service method (e.g. "AppendChildren").
+	Method string
+	// ObjectID is the ID of the primary object of the call. Empty for calls without one (e.g. Search).
+	ObjectID ObjectID
+
+	// HTTPMethod and Path (relative to the API version) of the endpoint.
+	HTTPMethod string
+	Path       string
+	// Query holds the query parameters of the request.
+	Query map[string]string
+	// Payload is the request body (e.g. *AppendBlockChildrenRequest). Nil for requests without body.
+	Payload any
+
+	decode     func(data []byte) (any, error)
+	basicAuth  bool
+	errDecoder errJSONDecodeFunc
+}
+
+// Handler executes an Operation. It returns the decoded response, which has the same type
+// as the corresponding service method returns (e.g. *Page for Pages.Get, Block for Blocks.Get).
+// Errors returned by Notion are *APIError.
+type Handler func(ctx context.Context, op *Operation) (any, error)
+
+// Middleware wraps a Handler. It's allowed to inspect or rewrite the operation,
+// inspect or replace the result, or to not call next at all (e.g. returning a cached result).
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middlewares to the client's chain.
+// Middlewares are called in the given order: the first one is the outermost.
+func WithMiddleware(middlewares ...Middleware) ClientOpt {
+	return func(c *Client) { c.api.middlewares = append(c.api.middlewares, middlewares...) }
+}
+
+// do runs the operation through the middleware chain.
+func (c *clientAPI) do(ctx context.Context, op *Operation) (any, error) {
+	handler := c.execute
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+
+	return handler(ctx, op)
+}
+
+// execute is the innermost Handler: it makes the HTTP request and decodes the response.
+func (c *clientAPI) execute(ctx context.Context, op *Operation) (result any, err error) {
+	errDecoder := op.errDecoder
+	if errDecoder == nil {
+		errDecoder = c.errDecoder
+	}
+
+	var meta ResponseInfo
+	defer func() { c.reportResponse(ctx, op, &meta, err) }()
+
+	res, err := c.requestRaw(ctx, op.HTTPMethod, op.Path, op.Query, op.Payload, op.basicAuth, errDecoder, &meta)
+	if err != nil {
+		return nil, err
+	}
+	defer c.closeBody(res)
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	meta.Body = data
+
+	if op.decode == nil {
+		return nil, nil
+	}
+	return op.decode(data)
+}
+
+// call runs the operation and asserts the result type.
+func call[T any](ctx context.Context, api *clientAPI, op *Operation) (T, error) {
+	var zero T
+
+	result, err := api.do(ctx, op)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := result.(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected result type %T for %s.%s", result, op.Service, op.Method)
+	}
+
+	return typed, nil
+}
+
+// decodeJSON decodes the response into a new *T.
+func decodeJSON[T any](data []byte) (any, error) {
+	var response T
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// decodeBlockJSON decodes the response into a Block.
+func decodeBlockJSON(data []byte) (any, error) {
+	return decodeBlock(data)
+}
@@ -0,0 +1,152 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should see the operation and the decoded result", func(t *testing.T) {
+		var seenOp *notion.Operation
+		var seenResult any
+		client := notion.New("some_token",
+			notion.WithTransport(newMockedClient(t, "testdata/block_update.json", http.StatusOK)),
+			notion.WithMiddleware(func(next notion.Handler) notion.Handler {
+				return func(ctx context.Context, op *notion.Operation) (any, error) {
+					seenOp = op
+					result, err := next(ctx, op)
+					seenResult = result
+					return result, err
+				}
+			}),
+		)
+
+		req := &notion.BlockUpdateRequest{
+			Paragraph: &notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText("Hello")}},
+		}
+		got, err := client.Blocks.Update(ctx, "some_id", req)
+		require.NoError(t, err)
+
+		require.NotNil(t, seenOp)
+		assert.Equal(t, notion.ServiceBlocks, seenOp.Service)
+		assert.Equal(t, "Update", seenOp.Method)
+		assert.Equal(t, notion.ObjectID("some_id"), seenOp.ObjectID)
+		assert.Equal(t, http.MethodPatch, seenOp.HTTPMethod)
+		assert.Equal(t, req, seenOp.Payload)
+		assert.Equal(t, got, seenResult)
+	})
+
+	t.Run("should see API errors", func(t *testing.T) {
+		var seenErr error
+		client := notion.New("some_token",
+			notion.WithTransport(newMockedClient(t, "testdata/validation_error.json", http.StatusBadRequest)),
+			notion.WithMiddleware(func(next notion.Handler) notion.Handler {
+				return func(ctx context.Context, op *notion.Operation) (any, error) {
+					result, err := next(ctx, op)
+					seenErr = err
+					return result, err
+				}
+			}),
+		)
+
+		_, err := client.Pages.Get(ctx, "some_id")
+
+		var apiErr *notion.APIError
+		assert.ErrorAs(t, seenErr, &apiErr)
+		assert.Equal(t, err, seenErr)
+	})
+
+	t.Run("should be able to short-circuit the request", func(t *testing.T) {
+		cached := &notion.Page{AtomID: notion.AtomID{ID: "cached"}}
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			t.Fatal("transport must not be called")
+			return nil
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithMiddleware(func(next notion.Handler) notion.Handler {
+				return func(ctx context.Context, op *notion.Operation) (any, error) {
+					if op.Service == notion.ServicePages && op.Method == "Get" {
+						return cached, nil
+					}
+					return next(ctx, op)
+				}
+			}),
+		)
+
+		got, err := client.Pages.Get(ctx, "cached")
+		require.NoError(t, err)
+		assert.Same(t, cached, got)
+	})
+
+	t.Run("should fail on unexpected result type", func(t *testing.T) {
+		client := notion.New("some_token",
+			notion.WithMiddleware(func(notion.Handler) notion.Handler {
+				return func(context.Context, *notion.Operation) (any, error) {
+					return "not a page", nil
+				}
+			}),
+		)
+
+		_, err := client.Pages.Get(ctx, "some_id")
+		assert.Error(t, err)
+	})
+
+	t.Run("should be able to rewrite the request", func(t *testing.T) {
+		var sentBody map[string]any
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			data, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &sentBody))
+			return mockedResponse(t, "testdata/search.json", http.StatusOK)
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithMiddleware(func(next notion.Handler) notion.Handler {
+				return func(ctx context.Context, op *notion.Operation) (any, error) {
+					if req, ok := op.Payload.(*notion.SearchRequest); ok {
+						rewritten := *req
+						rewritten.PageSize = 10
+						op.Payload = &rewritten
+					}
+					return next(ctx, op)
+				}
+			}),
+		)
+
+		_, err := client.Search.Do(ctx, &notion.SearchRequest{Query: "Hel"})
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, sentBody["page_size"])
+	})
+
+	t.Run("should call middlewares in order", func(t *testing.T) {
+		var calls []string
+		named := func(name string) notion.Middleware {
+			return func(next notion.Handler) notion.Handler {
+				return func(ctx context.Context, op *notion.Operation) (any, error) {
+					calls = append(calls, name)
+					return next(ctx, op)
+				}
+			}
+		}
+		client := notion.New("some_token",
+			notion.WithTransport(newMockedClient(t, "testdata/user_me.json", http.StatusOK)),
+			notion.WithMiddleware(named("first"), named("second")),
+			notion.WithMiddleware(named("third")),
+		)
+
+		_, err := client.Users.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second", "third"}, calls)
+	})
+}
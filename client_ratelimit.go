@@ -0,0 +1,124 @@
+package notion
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerSecond is the average rate limit Notion allows per integration.
+//
+// See https://developers.notion.com/reference/request-limits#rate-limits
+const DefaultRequestsPerSecond = 3
+
+// RateLimiter is a client-side token bucket limiter.
+// It's safe for concurrent use: a single limiter is shared by all the services of a Client,
+// and it can be shared between several clients using the same integration token.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	stats RateLimitStats
+}
+
+// RateLimitStats holds statistics of a RateLimiter.
+type RateLimitStats struct {
+	// Requests is the number of requests that passed through the limiter.
+	Requests int64
+	// Delayed is the number of requests that had to wait for a token.
+	Delayed int64
+	// TotalWait is the sum of all waits.
+	TotalWait time.Duration
+	// MaxWait is the longest single wait.
+	MaxWait time.Duration
+}
+
+// NewRateLimiter returns a new RateLimiter that allows requestsPerSecond on average
+// with bursts of up to burst requests.
+// A non-positive rate disables limiting, a non-positive burst is treated as 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a request is allowed to be sent or the context is done.
+// It returns the time spent waiting.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, ctx.Err()
+	}
+
+	wait := l.reserve(time.Now())
+	if wait == 0 {
+		return 0, ctx.Err()
+	}
+
+	if err := sleepContext(ctx, wait); err != nil {
+		l.cancel()
+		return 0, err
+	}
+
+	l.mu.Lock()
+	l.stats.Delayed++
+	l.stats.TotalWait += wait
+	l.stats.MaxWait = max(l.stats.MaxWait, wait)
+	l.mu.Unlock()
+
+	return wait, nil
+}
+
+// Stats returns a snapshot of the limiter statistics.
+func (l *RateLimiter) Stats() RateLimitStats {
+	if l == nil {
+		return RateLimitStats{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// reserve takes a token (possibly going into debt) and returns how long the caller must wait.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	if l.rate <= 0 {
+		return 0
+	}
+
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back the token taken by an aborted reservation.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests--
+	if l.rate > 0 {
+		l.tokens = min(l.burst, l.tokens+1)
+	}
+}
@@ -0,0 +1,82 @@
+package notion_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("should delay requests over the burst", func(t *testing.T) {
+		limiter := notion.NewRateLimiter(100, 1)
+
+		start := time.Now()
+		for range 3 {
+			_, err := limiter.Wait(context.Background())
+			require.NoError(t, err)
+		}
+
+		stats := limiter.Stats()
+		assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+		assert.EqualValues(t, 3, stats.Requests)
+		assert.EqualValues(t, 2, stats.Delayed)
+		assert.Positive(t, stats.TotalWait)
+		assert.Positive(t, stats.MaxWait)
+	})
+
+	t.Run("should respect context cancellation", func(t *testing.T) {
+		limiter := notion.NewRateLimiter(0.1, 1)
+
+		_, err := limiter.Wait(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = limiter.Wait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.EqualValues(t, 1, limiter.Stats().Requests)
+	})
+
+	t.Run("should be shared by all services", func(t *testing.T) {
+		transport := RoundTripFunc(func(req *http.Request) *http.Response {
+			switch {
+			case req.URL.Path == "/v1/users/me":
+				return mockedResponse(t, "testdata/user_me.json", http.StatusOK)
+			case req.URL.Path == "/v1/pages/some_id":
+				return mockedResponse(t, "testdata/page_get.json", http.StatusOK)
+			default:
+				return mockedResponse(t, "testdata/block_get.json", http.StatusOK)
+			}
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRateLimit(1000, 1),
+		)
+
+		var wg sync.WaitGroup
+		for range 3 {
+			wg.Add(3)
+			go func() { defer wg.Done(); _, _ = client.Blocks.Get(context.Background(), "some_id") }()
+			go func() { defer wg.Done(); _, _ = client.Pages.Get(context.Background(), "some_id") }()
+			go func() { defer wg.Done(); _, _ = client.Users.Me(context.Background()) }()
+		}
+		wg.Wait()
+
+		stats := client.RateLimitStats()
+		assert.EqualValues(t, 9, stats.Requests)
+		assert.Positive(t, stats.Delayed)
+	})
+
+	t.Run("should return zero stats when disabled", func(t *testing.T) {
+		client := notion.New("some_token")
+		assert.Equal(t, notion.RateLimitStats{}, client.RateLimitStats())
+	})
+}
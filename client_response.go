@@ -0,0 +1,66 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ResponseInfo holds metadata of the HTTP response of a single Operation.
+// When the request was retried, it describes the last attempt.
+type ResponseInfo struct {
+	// Method and Path of the HTTP request.
+	Method string
+	Path   string
+
+	StatusCode int
+	Header     http.Header
+	// RequestID is the Notion's request ID (X-Request-Id header). Useful for support tickets.
+	RequestID string
+
+	// Duration is the total time of the operation, including retries and rate-limit waits.
+	Duration time.Duration
+	// Attempts is the number of HTTP requests made.
+	Attempts int
+
+	// Body is the raw response body. It's set for both successful and failed responses.
+	Body []byte
+}
+
+// ResponseHook is called after every operation that got a response from Notion,
+// successful or not. err is the error the service method returns (e.g. *APIError or a decoding error).
+type ResponseHook func(ctx context.Context, op *Operation, info *ResponseInfo, err error)
+
+// WithResponseHook appends hooks that are called with the response metadata of every operation.
+func WithResponseHook(hooks ...ResponseHook) ClientOpt {
+	return func(c *Client) { c.api.responseHooks = append(c.api.responseHooks, hooks...) }
+}
+
+type responseInfoKey struct{}
+
+// ContextWithResponseInfo returns a context that collects the response metadata into info.
+// Use it for a single call:
+//
+//	var info notion.ResponseInfo
+//	page, err := client.Pages.Get(notion.ContextWithResponseInfo(ctx, &info), id)
+//	log.Println(info.RequestID)
+//
+// If the context is used for several calls, info describes the last one.
+func ContextWithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey{}, info)
+}
+
+// reportResponse passes the response metadata to the context collector and to the hooks.
+func (c *clientAPI) reportResponse(ctx context.Context, op *Operation, info *ResponseInfo, err error) {
+	if info.StatusCode == 0 {
+		// no response at all (e.g. network error)
+		return
+	}
+
+	if collector, ok := ctx.Value(responseInfoKey{}).(*ResponseInfo); ok && collector != nil {
+		*collector = *info
+	}
+	for _, hook := range c.responseHooks {
+		hook(ctx, op, info, err)
+	}
+}
@@ -0,0 +1,80 @@
+package notion_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func TestResponseInfo(t *testing.T) {
+	ctx := context.Background()
+
+	respondWith := func(file string, status int) http.RoundTripper {
+		return RoundTripFunc(func(*http.Request) *http.Response {
+			f, err := os.Open(file)
+			require.NoError(t, err)
+			return &http.Response{
+				StatusCode: status,
+				Body:       f,
+				Header:     http.Header{"X-Request-Id": []string{"req-1"}, "Content-Type": []string{"application/json"}},
+			}
+		})
+	}
+
+	t.Run("should collect metadata into the context", func(t *testing.T) {
+		client := notion.New("some_token", notion.WithTransport(respondWith("testdata/user_me.json", http.StatusOK)))
+
+		var info notion.ResponseInfo
+		_, err := client.Users.Me(notion.ContextWithResponseInfo(ctx, &info))
+		require.NoError(t, err)
+
+		expectedBody, err := os.ReadFile("testdata/user_me.json")
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodGet, info.Method)
+		assert.Equal(t, "/v1/users/me", info.Path)
+		assert.Equal(t, http.StatusOK, info.StatusCode)
+		assert.Equal(t, "req-1", info.RequestID)
+		assert.Equal(t, "application/json", info.Header.Get("Content-Type"))
+		assert.Equal(t, 1, info.Attempts)
+		assert.Equal(t, expectedBody, info.Body)
+	})
+
+	t.Run("should collect metadata of failed responses", func(t *testing.T) {
+		client := notion.New("some_token", notion.WithTransport(respondWith("testdata/validation_error.json", http.StatusBadRequest)))
+
+		var info notion.ResponseInfo
+		_, err := client.Pages.Get(notion.ContextWithResponseInfo(ctx, &info), "some_id")
+		require.Error(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, info.StatusCode)
+		assert.Equal(t, "req-1", info.RequestID)
+		assert.Contains(t, string(info.Body), "validation_error")
+	})
+
+	t.Run("should call response hooks", func(t *testing.T) {
+		var seenOp *notion.Operation
+		var seenInfo *notion.ResponseInfo
+		var seenErr error
+		client := notion.New("some_token",
+			notion.WithTransport(respondWith("testdata/validation_error.json", http.StatusBadRequest)),
+			notion.WithResponseHook(func(_ context.Context, op *notion.Operation, info *notion.ResponseInfo, err error) {
+				seenOp, seenInfo, seenErr = op, info, err
+			}),
+		)
+
+		_, err := client.Pages.Get(ctx, "some_id")
+
+		require.NotNil(t, seenInfo)
+		assert.Equal(t, notion.ServicePages, seenOp.Service)
+		assert.Equal(t, "Get", seenOp.Method)
+		assert.Equal(t, http.StatusBadRequest, seenInfo.StatusCode)
+		assert.Equal(t, err, seenErr)
+	})
+}
@@ -0,0 +1,203 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how the client retries failed requests.
+//
+// A request is retried when Notion responds with one of RetryableStatuses
+// (429 and 5xx by default) or when the transport of an idempotent request (GET, DELETE)
+// fails with a temporary network error. Server errors other than 429 and 503 are retried
+// for idempotent requests only, unless RetryNonIdempotent is set. Waits grow exponentially from InitialBackoff up to MaxBackoff
+// and are jittered. If the response carries a Retry-After header (either in
+// seconds or as an HTTP-date) it takes precedence over the computed backoff.
+//
+// See https://developers.notion.com/reference/request-limits
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts (including the first one).
+	// Values lower than 1 are treated as 1 (no retries).
+	MaxAttempts int
+
+	// InitialBackoff is the base wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps a single wait between two attempts.
+	MaxBackoff time.Duration
+	// MaxTotalWait caps the sum of all waits for a single request.
+	// Zero means no cap.
+	MaxTotalWait time.Duration
+
+	// Jitter is a fraction [0..1] of the computed backoff that is randomized.
+	// 0 disables jitter, 1 stands for the "full jitter" strategy.
+	Jitter float64
+
+	// RetryableStatuses lists HTTP status codes that are worth retrying.
+	RetryableStatuses []int
+	// RetryNetworkErrors enables retrying temporary transport errors
+	// (timeouts, connection resets, unexpected EOFs) of idempotent requests (GET, DELETE).
+	RetryNetworkErrors bool
+	// RetryNonIdempotent enables retrying temporary transport errors and server errors (other than 429 and 503)
+	// of POST and PATCH requests too. Such a request may have been applied by Notion before failing,
+	// so retrying it may create duplicate pages, blocks or comments.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by the client unless overridden.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    maxRetries,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		MaxTotalWait:   2 * time.Minute,
+		Jitter:         0.5,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// NoRetryPolicy returns a RetryPolicy that never retries.
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// attempts returns the normalized number of attempts.
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// isRetryableStatus returns true if the given status code of a request with the given method
+// is listed in RetryableStatuses and is worth retrying.
+// Notion doesn't process requests rejected with 429 or 503, so they are retried for any method,
+// while other statuses (e.g. 500 or 504) may come after a POST or PATCH has been applied.
+func (p RetryPolicy) isRetryableStatus(method string, status int) bool {
+	if !slices.Contains(p.RetryableStatuses, status) {
+		return false
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	default:
+		return p.RetryNonIdempotent || isIdempotent(method)
+	}
+}
+
+// isRetryableError returns true if the given transport error of a request with the given method is worth retrying.
+func (p RetryPolicy) isRetryableError(method string, err error) bool {
+	if !p.RetryNetworkErrors || err == nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isIdempotent returns true if repeating the request with the given method has the same effect as making it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the given retry (1-based).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	wait := p.InitialBackoff
+	for i := 1; i < retry; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 {
+		return wait
+	}
+
+	randomized := time.Duration(float64(wait) * jitter)
+	// nolint:gosec // jitter does not require a cryptographically secure source
+	return wait - randomized + time.Duration(rand.Int64N(int64(randomized)+1))
+}
+
+// parseRetryAfter parses the Retry-After header value. Both forms
+// (delay-seconds and HTTP-date) are supported.
+// The second returned value is false when the header is missing or malformed.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
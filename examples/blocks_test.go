@@ -12,7 +12,7 @@ func ExampleBlocksService_GetChildren() {
 
 	envCfg := loadEnv()
 
-	client := notion.New(envCfg.Token)
+	client := notion.New(envCfg.Token, notion.WithTransport(envCfg.Transport))
 
 	blocks, err := client.Blocks.GetChildren(ctx, envCfg.PageID, nil)
 	if err != nil {
@@ -13,7 +13,7 @@ func ExamplePagesService_Get() {
 
 	envCfg := loadEnv()
 
-	client := notion.New(envCfg.Token)
+	client := notion.New(envCfg.Token, notion.WithTransport(envCfg.Transport))
 
 	page, err := client.Pages.Get(ctx, envCfg.PageID)
 	if err != nil {
@@ -2,19 +2,79 @@ package examples_test
 
 import (
 	"log"
+	"net/http"
 	"os"
+	"testing"
 
 	"github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
 
 	"github.com/joho/godotenv"
 )
 
+// Examples are replayed from the cassette, so they run offline.
+// The committed cassette was recorded against the notiontest fake server, not the real Notion API
+// (its IDs and timestamps are the fake ones), so it doesn't prove the examples work against Notion.
+// To re-record it against a real workspace, run them with
+// NOTION_CASSETTE_MODE=record (or record_if_missing), NOTION_API_TOKEN and NOTION_EXAMPLE_PAGE_ID set.
+const (
+	cassettePath = "testdata/cassette.json"
+
+	// examplePageID replaces the real example page ID in the cassette.
+	examplePageID = "17341c5d-8c48-813f-a7c2-d5ed3886e104"
+)
+
 type ExamplesConfig struct {
-	Token  notion.Token
-	PageID notion.ObjectID
+	Token     notion.Token
+	PageID    notion.ObjectID
+	Transport http.RoundTripper
+}
+
+var recorder *notiontest.Recorder
+
+func TestMain(m *testing.M) {
+	mode, err := notiontest.ParseMode(os.Getenv("NOTION_CASSETTE_MODE"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pageID := examplePageID
+	if mode != notiontest.ModeReplay {
+		pageID = mustEnv("NOTION_EXAMPLE_PAGE_ID")
+	}
+
+	recorder, err = notiontest.NewRecorder(cassettePath, mode,
+		notiontest.WithPlaceholder(pageID, examplePageID),
+		notiontest.WithScrubFields("email"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	code := m.Run()
+	if err := recorder.Save(); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(code)
 }
 
 func loadEnv() ExamplesConfig {
+	if recorder.Mode() == notiontest.ModeReplay {
+		return ExamplesConfig{
+			Token:     "secret_replay",
+			PageID:    examplePageID,
+			Transport: recorder,
+		}
+	}
+
+	return ExamplesConfig{
+		Token:     notion.Token(mustEnv("NOTION_API_TOKEN")),
+		PageID:    notion.ObjectID(mustEnv("NOTION_EXAMPLE_PAGE_ID")),
+		Transport: recorder,
+	}
+}
+
+func mustEnv(name string) string {
 	err := godotenv.Load(".env")
 	if os.IsNotExist(err) {
 		// having `.env` is optional, so we're OK here
@@ -22,18 +82,9 @@ func loadEnv() ExamplesConfig {
 		log.Fatal("failed to read .env")
 	}
 
-	token := os.Getenv("NOTION_API_TOKEN")
-	if token == "" {
-		log.Fatal("NOTION_API_TOKEN env var is not set")
-	}
-
-	examplePageID := os.Getenv("NOTION_EXAMPLE_PAGE_ID")
-	if examplePageID == "" {
-		log.Fatal("NOTION_EXAMPLE_PAGE_ID env var is required")
-	}
-
-	return ExamplesConfig{
-		Token:  notion.Token(token),
-		PageID: notion.ObjectID(examplePageID),
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("%s env var is required for recording", name)
 	}
+	return value
 }
@@ -1,27 +1,176 @@
 package notion
 
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
 // ErrorCode is a type for Notion API error codes.
 type ErrorCode string
 
+// String returns the string representation of the ErrorCode.
+func (c ErrorCode) String() string { return string(c) }
+
+// See https://developers.notion.com/reference/status-codes#error-codes
+// nolint:revive
+const (
+	ErrorCodeInvalidJSON       ErrorCode = "invalid_json"
+	ErrorCodeInvalidRequestURL ErrorCode = "invalid_request_url"
+	ErrorCodeInvalidRequest    ErrorCode = "invalid_request"
+	ErrorCodeInvalidGrant      ErrorCode = "invalid_grant"
+	ErrorCodeValidation        ErrorCode = "validation_error"
+	ErrorCodeMissingVersion    ErrorCode = "missing_version"
+
+	ErrorCodeUnauthorized       ErrorCode = "unauthorized"
+	ErrorCodeRestrictedResource ErrorCode = "restricted_resource"
+	ErrorCodeObjectNotFound     ErrorCode = "object_not_found"
+	ErrorCodeConflict           ErrorCode = "conflict_error"
+	ErrorCodeRateLimited        ErrorCode = "rate_limited"
+
+	ErrorCodeInternalServer                ErrorCode = "internal_server_error"
+	ErrorCodeBadGateway                    ErrorCode = "bad_gateway"
+	ErrorCodeServiceUnavailable            ErrorCode = "service_unavailable"
+	ErrorCodeDatabaseConnectionUnavailable ErrorCode = "database_connection_unavailable"
+	ErrorCodeGatewayTimeout                ErrorCode = "gateway_timeout"
+)
+
+// Sentinel errors to be used with errors.Is. Every *APIError matches
+// the sentinel of its Code, e.g. errors.Is(err, ErrObjectNotFound).
+// nolint:revive
+var (
+	ErrInvalidJSON       = errors.New("notion: invalid json")
+	ErrInvalidRequestURL = errors.New("notion: invalid request url")
+	ErrInvalidRequest    = errors.New("notion: invalid request")
+	ErrInvalidGrant      = errors.New("notion: invalid grant")
+	ErrValidation        = errors.New("notion: validation error")
+	ErrMissingVersion    = errors.New("notion: missing version")
+
+	// ErrUnauthorized means the bearer token is not valid.
+	ErrUnauthorized = errors.New("notion: unauthorized")
+	// ErrRestrictedResource means the integration doesn't have capabilities to perform the operation.
+	ErrRestrictedResource = errors.New("notion: restricted resource")
+	// ErrObjectNotFound means the resource does not exist, or it's not shared with the integration.
+	ErrObjectNotFound = errors.New("notion: object not found")
+	ErrConflict       = errors.New("notion: conflict")
+	ErrRateLimited    = errors.New("notion: rate limited")
+
+	ErrInternalServer                = errors.New("notion: internal server error")
+	ErrBadGateway                    = errors.New("notion: bad gateway")
+	ErrServiceUnavailable            = errors.New("notion: service unavailable")
+	ErrDatabaseConnectionUnavailable = errors.New("notion: database connection unavailable")
+	ErrGatewayTimeout                = errors.New("notion: gateway timeout")
+)
+
+// errorCodeSentinels maps error codes to the sentinel errors.
+var errorCodeSentinels = map[ErrorCode]error{
+	ErrorCodeInvalidJSON:                   ErrInvalidJSON,
+	ErrorCodeInvalidRequestURL:             ErrInvalidRequestURL,
+	ErrorCodeInvalidRequest:                ErrInvalidRequest,
+	ErrorCodeInvalidGrant:                  ErrInvalidGrant,
+	ErrorCodeValidation:                    ErrValidation,
+	ErrorCodeMissingVersion:                ErrMissingVersion,
+	ErrorCodeUnauthorized:                  ErrUnauthorized,
+	ErrorCodeRestrictedResource:            ErrRestrictedResource,
+	ErrorCodeObjectNotFound:                ErrObjectNotFound,
+	ErrorCodeConflict:                      ErrConflict,
+	ErrorCodeRateLimited:                   ErrRateLimited,
+	ErrorCodeInternalServer:                ErrInternalServer,
+	ErrorCodeBadGateway:                    ErrBadGateway,
+	ErrorCodeServiceUnavailable:            ErrServiceUnavailable,
+	ErrorCodeDatabaseConnectionUnavailable: ErrDatabaseConnectionUnavailable,
+	ErrorCodeGatewayTimeout:                ErrGatewayTimeout,
+}
+
+// Sentinel returns the sentinel error of the ErrorCode (or nil for unknown codes).
+func (c ErrorCode) Sentinel() error { return errorCodeSentinels[c] }
+
+// errorCodeForStatus guesses the ErrorCode for responses that have no (valid) JSON body,
+// e.g. errors produced by proxies.
+func errorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeInvalidRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeRestrictedResource
+	case http.StatusNotFound:
+		return ErrorCodeObjectNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case http.StatusBadGateway:
+		return ErrorCodeBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrorCodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrorCodeGatewayTimeout
+	default:
+		return ErrorCodeInternalServer
+	}
+}
+
 // APIError is a type for Notion API errors.
 type APIError struct {
-	Object  ObjectType `json:"object"`
-	Status  int        `json:"status"`
-	Code    ErrorCode  `json:"code"`
-	Message string     `json:"message"`
+	Object    ObjectType `json:"object"`
+	Status    int        `json:"status"`
+	Code      ErrorCode  `json:"code"`
+	Message   string     `json:"message"`
+	RequestID string     `json:"request_id,omitempty"`
+
+	// Method and Path of the failed request.
+	Method string `json:"-"`
+	Path   string `json:"-"`
+
+	// RetryAfter is the value of the Retry-After response header (if any).
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string { return e.Message }
 
+// Is makes APIError match the sentinel error of its Code.
+func (e *APIError) Is(target error) bool {
+	sentinel := e.Code.Sentinel()
+	return sentinel != nil && sentinel == target
+}
+
 // RateLimitedError is a type for rate-limited errors.
+// It's returned when a request is still rate-limited after all the retries.
 type RateLimitedError struct {
 	Message string
+
+	// Attempts is the number of attempts made.
+	Attempts int
+	// RetryAfter is the last Retry-After value suggested by Notion.
+	RetryAfter time.Duration
+	// RequestID is the Notion's request ID of the last attempt.
+	RequestID string
+
+	// Method and Path of the failed request.
+	Method string
+	Path   string
+
+	// Err is the error returned by Notion on the last attempt (if it was decodable).
+	Err *APIError
 }
 
 // Error implements the error interface.
 func (e *RateLimitedError) Error() string { return e.Message }
 
+// Is makes RateLimitedError match ErrRateLimited.
+func (e *RateLimitedError) Is(target error) bool { return target == ErrRateLimited }
+
+// Unwrap returns the underlying APIError.
+func (e *RateLimitedError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
 // TokenCreateError is a type for token creation errors.
 type TokenCreateError struct {
 	Code    ErrorCode `json:"error"`
@@ -30,3 +179,9 @@ type TokenCreateError struct {
 
 // Error implements the error interface.
 func (e *TokenCreateError) Error() string { return e.Message }
+
+// Is makes TokenCreateError match the sentinel error of its Code.
+func (e *TokenCreateError) Is(target error) bool {
+	sentinel := e.Code.Sentinel()
+	return sentinel != nil && sentinel == target
+}
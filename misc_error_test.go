@@ -0,0 +1,105 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func TestAPIError(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should match sentinel errors and carry request details", func(t *testing.T) {
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			file, err := os.Open("testdata/object_not_found.json")
+			require.NoError(t, err)
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       file,
+				Header:     http.Header{"X-Request-Id": []string{"req-404"}},
+			}
+		})
+		client := notion.New("some_token", notion.WithTransport(transport))
+
+		_, err := client.Pages.Get(ctx, "some_id")
+		require.Error(t, err)
+
+		assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+		assert.NotErrorIs(t, err, notion.ErrValidation)
+
+		var apiErr *notion.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, notion.ErrorCodeObjectNotFound, apiErr.Code)
+		assert.Equal(t, http.StatusNotFound, apiErr.Status)
+		assert.Equal(t, "req-404", apiErr.RequestID)
+		assert.Equal(t, http.MethodGet, apiErr.Method)
+		assert.Equal(t, "/v1/pages/some_id", apiErr.Path)
+	})
+
+	t.Run("should guess the code of non-JSON responses", func(t *testing.T) {
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(strings.NewReader("<html>Bad Gateway</html>")),
+				Header:     make(http.Header),
+			}
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetryPolicy(notion.NoRetryPolicy()),
+		)
+
+		_, err := client.Users.Me(ctx)
+
+		assert.ErrorIs(t, err, notion.ErrBadGateway)
+		var apiErr *notion.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.Status)
+	})
+
+	t.Run("should wrap the last rate-limited response", func(t *testing.T) {
+		transport := RoundTripFunc(func(*http.Request) *http.Response {
+			body := `{"object":"error","status":429,"code":"rate_limited","message":"You have been rate limited."}`
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Retry-After": []string{"0"}, "X-Request-Id": []string{"req-429"}},
+			}
+		})
+		client := notion.New("some_token",
+			notion.WithTransport(transport),
+			notion.WithRetry(2),
+		)
+
+		_, err := client.Blocks.Get(ctx, "some_block_id")
+
+		assert.ErrorIs(t, err, notion.ErrRateLimited)
+
+		var rateLimitedErr *notion.RateLimitedError
+		require.ErrorAs(t, err, &rateLimitedErr)
+		assert.Equal(t, 2, rateLimitedErr.Attempts)
+		assert.Equal(t, time.Duration(0), rateLimitedErr.RetryAfter)
+		assert.Equal(t, "req-429", rateLimitedErr.RequestID)
+		assert.Equal(t, "/v1/blocks/some_block_id", rateLimitedErr.Path)
+
+		var apiErr *notion.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, notion.ErrorCodeRateLimited, apiErr.Code)
+	})
+
+	t.Run("should match token creation errors", func(t *testing.T) {
+		err := error(&notion.TokenCreateError{Code: notion.ErrorCodeInvalidGrant})
+
+		assert.True(t, errors.Is(err, notion.ErrInvalidGrant))
+	})
+}
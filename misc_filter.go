@@ -65,7 +65,7 @@ const (
 	ConditionLessThan       Condition = "less_than"
 
 	ConditionGreaterThanOrEqualTo Condition = "greater_than_or_equal_to"
-	ConditionLessThanOrEqualTo    Condition = "greater_than_or_equal_to"
+	ConditionLessThanOrEqualTo    Condition = "less_than_or_equal_to"
 
 	ConditionBefore     Condition = "before"
 	ConditionAfter      Condition = "after"
@@ -1,6 +1,9 @@
 package notion
 
-import "strconv"
+import (
+	"iter"
+	"strconv"
+)
 
 // Cursor is the Notion's cursor value from the pagination.
 type Cursor string
@@ -40,3 +43,48 @@ func (p *Pagination) ToQuery() map[string]string {
 
 	return r
 }
+
+// paginate returns an iterator over the items of all the pages of a paginated list,
+// starting with the given cursor. fetch requests one page by the cursor.
+// Iteration stops on the first error (it's yielded) or when the caller stops it.
+func paginate[T any](start Cursor, fetch func(cursor Cursor) ([]T, AtomPaginatedResponse, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := start
+		for {
+			items, page, err := fetch(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !page.HasMore || page.NextCursor == EmptyCursor {
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// withCursor returns a copy of the pagination with the given start cursor.
+func (p *Pagination) withCursor(cursor Cursor) *Pagination {
+	result := &Pagination{StartCursor: cursor}
+	if p != nil {
+		result.PageSize = p.PageSize
+	}
+	return result
+}
+
+// startCursor returns the start cursor of the pagination (nil-safe).
+func (p *Pagination) startCursor() Cursor {
+	if p == nil {
+		return EmptyCursor
+	}
+	return p.StartCursor
+}
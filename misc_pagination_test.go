@@ -0,0 +1,243 @@
+package notion_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// pagedRequest is a request received by pagedTransport.
+type pagedRequest struct {
+	StartCursor string `json:"start_cursor"`
+	PageSize    int    `json:"page_size"`
+}
+
+// pagedTransport serves a list of total items (built by item) in pages, using item indexes as cursors.
+// The default page size is 2. Requests are recorded. failAt makes the request with this cursor fail.
+func pagedTransport(t *testing.T, total int, item func(i int) string, failAt string, requests *[]pagedRequest) http.RoundTripper {
+	return RoundTripFunc(func(req *http.Request) *http.Response {
+		var r pagedRequest
+		if req.Body != nil {
+			data, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			if len(data) > 0 {
+				require.NoError(t, json.Unmarshal(data, &r))
+			}
+		}
+		if cursor := req.URL.Query().Get("start_cursor"); cursor != "" {
+			r.StartCursor = cursor
+		}
+		if size := req.URL.Query().Get("page_size"); size != "" {
+			r.PageSize, _ = strconv.Atoi(size)
+		}
+		*requests = append(*requests, r)
+
+		if failAt != "" && r.StartCursor == failAt {
+			return mockedResponse(t, "testdata/validation_error.json", http.StatusBadRequest)
+		}
+
+		start, _ := strconv.Atoi(r.StartCursor)
+		size := r.PageSize
+		if size == 0 {
+			size = 2
+		}
+		end := min(start+size, total)
+
+		results := make([]json.RawMessage, 0, end-start)
+		for i := start; i < end; i++ {
+			results = append(results, json.RawMessage(item(i)))
+		}
+		var nextCursor any
+		if end < total {
+			nextCursor = strconv.Itoa(end)
+		}
+
+		body, err := json.Marshal(map[string]any{
+			"object":      "list",
+			"results":     results,
+			"next_cursor": nextCursor,
+			"has_more":    end < total,
+		})
+		require.NoError(t, err)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Header:     make(http.Header),
+		}
+	})
+}
+
+// collect returns IDs of all the iterated items and the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]string, error) {
+	ids := make([]string, 0)
+	for item, err := range seq {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, any(item).(interface{ GetID() notion.ObjectID }).GetID().String())
+	}
+	return ids, nil
+}
+
+func TestPaginationIterators(t *testing.T) {
+	ctx := context.Background()
+
+	blockItem := func(i int) string {
+		return fmt.Sprintf(`{"object":"block","id":"%d","type":"divider","divider":{}}`, i)
+	}
+	pageItem := func(i int) string {
+		return fmt.Sprintf(`{"object":"page","id":"%d","properties":{}}`, i)
+	}
+	userItem := func(i int) string {
+		return fmt.Sprintf(`{"object":"user","id":"%d","type":"bot","bot":{}}`, i)
+	}
+	commentItem := func(i int) string {
+		return fmt.Sprintf(`{"object":"comment","id":"%d"}`, i)
+	}
+
+	tests := []struct {
+		name string
+		item func(i int) string
+		// iterate returns iterated IDs, page size is 0 for the default one
+		iterate func(client *notion.Client, pageSize int) ([]string, error)
+	}{
+		{
+			name: "Blocks.AllChildren",
+			item: blockItem,
+			iterate: func(client *notion.Client, pageSize int) ([]string, error) {
+				return collect(client.Blocks.AllChildren(ctx, "some_id", &notion.Pagination{PageSize: pageSize}))
+			},
+		},
+		{
+			name: "Databases.QueryAll",
+			item: pageItem,
+			iterate: func(client *notion.Client, pageSize int) ([]string, error) {
+				return collect(client.Databases.QueryAll(ctx, "some_id", &notion.DatabaseQueryRequest{PageSize: pageSize}))
+			},
+		},
+		{
+			name: "Search.All",
+			item: pageItem,
+			iterate: func(client *notion.Client, pageSize int) ([]string, error) {
+				return collect(client.Search.All(ctx, &notion.SearchRequest{PageSize: pageSize}))
+			},
+		},
+		{
+			name: "Users.ListAll",
+			item: userItem,
+			iterate: func(client *notion.Client, pageSize int) ([]string, error) {
+				return collect(client.Users.ListAll(ctx, &notion.Pagination{PageSize: pageSize}))
+			},
+		},
+		{
+			name: "Comments.GetAll",
+			item: commentItem,
+			iterate: func(client *notion.Client, pageSize int) ([]string, error) {
+				return collect(client.Comments.GetAll(ctx, "some_id", &notion.Pagination{PageSize: pageSize}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("should iterate over all pages", func(t *testing.T) {
+				var requests []pagedRequest
+				client := notion.New("some_token", notion.WithTransport(pagedTransport(t, 5, tt.item, "", &requests)))
+
+				ids, err := tt.iterate(client, 0)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"0", "1", "2", "3", "4"}, ids)
+				assert.Equal(t, []pagedRequest{{}, {StartCursor: "2"}, {StartCursor: "4"}}, requests)
+			})
+
+			t.Run("should honor page size", func(t *testing.T) {
+				var requests []pagedRequest
+				client := notion.New("some_token", notion.WithTransport(pagedTransport(t, 5, tt.item, "", &requests)))
+
+				ids, err := tt.iterate(client, 3)
+				require.NoError(t, err)
+				assert.Len(t, ids, 5)
+				assert.Equal(t, []pagedRequest{{PageSize: 3}, {StartCursor: "3", PageSize: 3}}, requests)
+			})
+
+			t.Run("should yield errors", func(t *testing.T) {
+				var requests []pagedRequest
+				client := notion.New("some_token", notion.WithTransport(pagedTransport(t, 5, tt.item, "2", &requests)))
+
+				ids, err := tt.iterate(client, 0)
+				require.ErrorIs(t, err, notion.ErrValidation)
+				assert.Equal(t, []string{"0", "1"}, ids)
+			})
+		})
+	}
+
+	t.Run("should stop requesting pages on break", func(t *testing.T) {
+		var requests []pagedRequest
+		client := notion.New("some_token", notion.WithTransport(pagedTransport(t, 5, blockItem, "", &requests)))
+
+		for block, err := range client.Blocks.AllChildren(ctx, "some_id", nil) {
+			require.NoError(t, err)
+			if block.GetID() == "2" {
+				break
+			}
+		}
+		assert.Len(t, requests, 2)
+	})
+
+	t.Run("should not modify the request", func(t *testing.T) {
+		var requests []pagedRequest
+		client := notion.New("some_token", notion.WithTransport(pagedTransport(t, 5, pageItem, "", &requests)))
+
+		request := &notion.DatabaseQueryRequest{StartCursor: "1"}
+		ids, err := collect(client.Databases.QueryAll(ctx, "some_id", request))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+		assert.Equal(t, notion.Cursor("1"), request.StartCursor)
+	})
+
+	t.Run("should be safe to range concurrently", func(t *testing.T) {
+		var mu sync.Mutex
+		var requests []pagedRequest
+		paged := pagedTransport(t, 5, pageItem, "", &requests)
+		transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return paged.RoundTrip(req)
+		})
+		client := notion.New("some_token", notion.WithTransport(transport))
+
+		queryAll := client.Databases.QueryAll(ctx, "some_id", &notion.DatabaseQueryRequest{StartCursor: "1"})
+		searchAll := client.Search.All(ctx, &notion.SearchRequest{StartCursor: "1"})
+
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				ids, err := collect(queryAll)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+			}()
+			go func() {
+				defer wg.Done()
+				ids, err := collect(searchAll)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -105,7 +105,7 @@ const (
 	FormatRupiah           FormatType = "rupiah"
 	FormatFranc            FormatType = "franc"
 	FormatHongKongDollar   FormatType = "hong_kong_dollar"
-	FormatNewZealandDollar FormatType = "hong_kong_dollar"
+	FormatNewZealandDollar FormatType = "new_zealand_dollar"
 	FormatKrona            FormatType = "krona"
 	FormatNorwegianKrone   FormatType = "norwegian_krone"
 	FormatMexicanPeso      FormatType = "mexican_peso"
@@ -512,7 +512,7 @@ type PropertyConfigs map[string]PropertyConfig
 
 // UnmarshalJSON implements custom unmarshalling for PropertyConfigs
 func (p *PropertyConfigs) UnmarshalJSON(data []byte) error {
-	var raw map[string]interface{}
+	var raw map[string]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
@@ -525,76 +525,76 @@ func (p *PropertyConfigs) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func parsePropertyConfigs(raw map[string]interface{}) (PropertyConfigs, error) {
-	result := make(PropertyConfigs)
+func parsePropertyConfigs(raw map[string]json.RawMessage) (PropertyConfigs, error) {
+	result := make(PropertyConfigs, len(raw))
 	for k, v := range raw {
+		var head struct {
+			Type *PropertyConfigType `json:"type"`
+		}
+		if err := json.Unmarshal(v, &head); err != nil {
+			return nil, err
+		}
+		if head.Type == nil {
+			return nil, fmt.Errorf("unsupported property format: missing type")
+		}
+
 		var p PropertyConfig
-		switch rawProperty := v.(type) {
-		case map[string]interface{}:
-			switch PropertyConfigType(rawProperty["type"].(string)) {
-			case PropertyConfigTypeTitle:
-				p = &TitlePropertyConfig{}
-			case PropertyConfigTypeRichText:
-				p = &RichTextPropertyConfig{}
-			case PropertyConfigTypeNumber:
-				p = &NumberPropertyConfig{}
-			case PropertyConfigTypeSelect:
-				p = &SelectPropertyConfig{}
-			case PropertyConfigTypeMultiSelect:
-				p = &MultiSelectPropertyConfig{}
-			case PropertyConfigTypeDate:
-				p = &DatePropertyConfig{}
-			case PropertyConfigTypePeople:
-				p = &PeoplePropertyConfig{}
-			case PropertyConfigTypeFiles:
-				p = &FilesPropertyConfig{}
-			case PropertyConfigTypeCheckbox:
-				p = &CheckboxPropertyConfig{}
-			case PropertyConfigTypeURL:
-				p = &URLPropertyConfig{}
-			case PropertyConfigTypeEmail:
-				p = &EmailPropertyConfig{}
-			case PropertyConfigTypePhoneNumber:
-				p = &PhoneNumberPropertyConfig{}
-			case PropertyConfigTypeFormula:
-				p = &FormulaPropertyConfig{}
-			case PropertyConfigTypeRelation:
-				p = &RelationPropertyConfig{}
-			case PropertyConfigTypeRollup:
-				p = &RollupPropertyConfig{}
-			case PropertyConfigCreatedTime:
-				p = &CreatedTimePropertyConfig{}
-			case PropertyConfigCreatedBy:
-				p = &CreatedTimePropertyConfig{}
-			case PropertyConfigLastEditedTime:
-				p = &LastEditedTimePropertyConfig{}
-			case PropertyConfigLastEditedBy:
-				p = &LastEditedByPropertyConfig{}
-			case PropertyConfigStatus:
-				p = &StatusPropertyConfig{}
-			case PropertyConfigUniqueID:
-				p = &UniqueIDPropertyConfig{}
-			case PropertyConfigVerification:
-				p = &VerificationPropertyConfig{}
-			case PropertyConfigButton:
-				p = &ButtonPropertyConfig{}
-			default:
-
-				return nil, fmt.Errorf("unsupported property type: %s", rawProperty["type"].(string))
-			}
-			b, err := json.Marshal(rawProperty)
-			if err != nil {
-				return nil, err
-			}
-
-			if err = json.Unmarshal(b, &p); err != nil {
-				return nil, err
-			}
-
-			result[k] = p
+		switch *head.Type {
+		case PropertyConfigTypeTitle:
+			p = &TitlePropertyConfig{}
+		case PropertyConfigTypeRichText:
+			p = &RichTextPropertyConfig{}
+		case PropertyConfigTypeNumber:
+			p = &NumberPropertyConfig{}
+		case PropertyConfigTypeSelect:
+			p = &SelectPropertyConfig{}
+		case PropertyConfigTypeMultiSelect:
+			p = &MultiSelectPropertyConfig{}
+		case PropertyConfigTypeDate:
+			p = &DatePropertyConfig{}
+		case PropertyConfigTypePeople:
+			p = &PeoplePropertyConfig{}
+		case PropertyConfigTypeFiles:
+			p = &FilesPropertyConfig{}
+		case PropertyConfigTypeCheckbox:
+			p = &CheckboxPropertyConfig{}
+		case PropertyConfigTypeURL:
+			p = &URLPropertyConfig{}
+		case PropertyConfigTypeEmail:
+			p = &EmailPropertyConfig{}
+		case PropertyConfigTypePhoneNumber:
+			p = &PhoneNumberPropertyConfig{}
+		case PropertyConfigTypeFormula:
+			p = &FormulaPropertyConfig{}
+		case PropertyConfigTypeRelation:
+			p = &RelationPropertyConfig{}
+		case PropertyConfigTypeRollup:
+			p = &RollupPropertyConfig{}
+		case PropertyConfigCreatedTime:
+			p = &CreatedTimePropertyConfig{}
+		case PropertyConfigCreatedBy:
+			p = &CreatedByPropertyConfig{}
+		case PropertyConfigLastEditedTime:
+			p = &LastEditedTimePropertyConfig{}
+		case PropertyConfigLastEditedBy:
+			p = &LastEditedByPropertyConfig{}
+		case PropertyConfigStatus:
+			p = &StatusPropertyConfig{}
+		case PropertyConfigUniqueID:
+			p = &UniqueIDPropertyConfig{}
+		case PropertyConfigVerification:
+			p = &VerificationPropertyConfig{}
+		case PropertyConfigButton:
+			p = &ButtonPropertyConfig{}
 		default:
-			return nil, fmt.Errorf("unsupported property format %T", v)
+			return nil, fmt.Errorf("unsupported property type: %s", *head.Type)
 		}
+
+		if err := json.Unmarshal(v, p); err != nil {
+			return nil, err
+		}
+
+		result[k] = p
 	}
 
 	return result, nil
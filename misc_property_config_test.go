@@ -46,3 +46,25 @@ func TestNumberPropertyConfig_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertyConfigs_UnmarshalJSON(t *testing.T) {
+	data := []byte(`{
+		"Created": {"id": "a", "type": "created_time", "created_time": {}},
+		"Author": {"id": "b", "type": "created_by", "created_by": {}},
+		"Editor": {"id": "c", "type": "last_edited_by", "last_edited_by": {}}
+	}`)
+
+	var got notion.PropertyConfigs
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+
+	want := notion.PropertyConfigs{
+		"Created": &notion.CreatedTimePropertyConfig{ID: "a", Type: notion.PropertyConfigCreatedTime, CreatedTime: map[string]any{}},
+		"Author":  &notion.CreatedByPropertyConfig{ID: "b", Type: notion.PropertyConfigCreatedBy, CreatedBy: map[string]any{}},
+		"Editor":  &notion.LastEditedByPropertyConfig{ID: "c", Type: notion.PropertyConfigLastEditedBy, LastEditedBy: map[string]any{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalJSON() got = %#v, want %#v", got, want)
+	}
+}
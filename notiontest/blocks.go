@@ -0,0 +1,315 @@
+package notiontest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// uncreatableBlockTypes are block types that can't be created via the API.
+var uncreatableBlockTypes = map[string]bool{
+	"child_page":     true,
+	"child_database": true,
+	"link_preview":   true,
+	"unsupported":    true,
+}
+
+// richTextFields are the fields of block contents holding rich texts.
+var richTextFields = []string{"rich_text", "caption"}
+
+func (s *Server) getBlock(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	block, ok := s.blocks[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+	return block, nil
+}
+
+func (s *Server) getBlockChildren(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	if _, ok := s.blocks[id]; !ok {
+		return nil, errNotFound(id)
+	}
+
+	params, apiErr := s.queryListParams(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return paginate(s.childBlocks(id), "block", params)
+}
+
+func (s *Server) handleAppendBlockChildren(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	id := normalizeID(r.PathValue("id"))
+	children, ok := body["children"].([]any)
+	if !ok {
+		return nil, errValidation("body failed validation: body.children should be defined, instead was `undefined`.")
+	}
+	if apiErr := validateNewBlocks(children, "body.children", 0); apiErr != nil {
+		return nil, apiErr
+	}
+
+	after, _ := body["after"].(string)
+	created, apiErr := s.appendBlocks(id, children, normalizeID(after))
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return object{
+		"object":      "list",
+		"results":     created,
+		"next_cursor": nil,
+		"has_more":    false,
+		"type":        "block",
+		"block":       object{},
+	}, nil
+}
+
+func (s *Server) handleUpdateBlock(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.updateBlock(normalizeID(r.PathValue("id")), body)
+}
+
+func (s *Server) deleteBlock(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	block, ok := s.blocks[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+	if isArchived(block) {
+		return nil, errArchived()
+	}
+
+	s.setObjectArchived(id, true)
+	s.touch(block)
+
+	return block, nil
+}
+
+// childBlocks returns the not archived children of the page or block.
+func (s *Server) childBlocks(id string) []object {
+	blocks := make([]object, 0, len(s.children[id]))
+	for _, childID := range s.children[id] {
+		if block := s.blocks[childID]; !isArchived(block) {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// appendBlocks validates the parent and inserts blocks into its children.
+func (s *Server) appendBlocks(parentID string, children []any, after string) ([]object, *notion.APIError) {
+	parent, ok := s.blocks[parentID]
+	if !ok {
+		return nil, errNotFound(parentID)
+	}
+	if isArchived(parent) {
+		return nil, errArchived()
+	}
+	if parent["type"] == "child_database" {
+		return nil, errValidation("Can't append children to a database. Create pages in it instead.")
+	}
+	if after != "" && !slices.Contains(s.children[parentID], after) {
+		return nil, errValidation("body failed validation: body.after should be a child block of %s, instead was `%s`.", parentID, after)
+	}
+
+	return s.insertBlocks(parentID, children, after), nil
+}
+
+// insertBlocks creates blocks (with their nested children) and inserts them into the parent's children,
+// after the given child or to the end.
+func (s *Server) insertBlocks(parentID string, children []any, after string) []object {
+	if len(children) == 0 {
+		return []object{}
+	}
+
+	parentType := "block_id"
+	if _, ok := s.pages[parentID]; ok {
+		parentType = "page_id"
+	}
+
+	created := make([]object, 0, len(children))
+	ids := make([]string, 0, len(children))
+	for _, v := range children {
+		input, ok := v.(object)
+		if !ok {
+			continue
+		}
+
+		blockType := blockTypeOf(input)
+		content, _ := input[blockType].(object)
+		content = cloneObject(content)
+		if content == nil {
+			content = object{}
+		}
+		nested, _ := content["children"].([]any)
+		delete(content, "children")
+		normalizeBlockContent(blockType, content)
+
+		id := newID()
+		now := s.timestamp()
+		block := object{
+			"object":           "block",
+			"id":               id,
+			"parent":           newParent(parentType, parentID),
+			"created_time":     now,
+			"last_edited_time": now,
+			"created_by":       s.userRef(),
+			"last_edited_by":   s.userRef(),
+			"has_children":     false,
+			"archived":         false,
+			"in_trash":         false,
+			"type":             blockType,
+			blockType:          content,
+		}
+		s.blocks[id] = block
+		s.insertBlocks(id, nested, "")
+
+		created = append(created, block)
+		ids = append(ids, id)
+	}
+
+	siblings := s.children[parentID]
+	position := len(siblings)
+	if after != "" {
+		position = slices.Index(siblings, after) + 1
+	}
+	s.children[parentID] = slices.Insert(siblings, position, ids...)
+
+	if parent, ok := s.blocks[parentID]; ok {
+		parent["has_children"] = true
+	}
+
+	return created
+}
+
+// updateBlock updates the type-specific content or the archived state of the block.
+func (s *Server) updateBlock(id string, body object) (object, *notion.APIError) {
+	block, ok := s.blocks[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+
+	archived, hasArchived := archivedFlag(body)
+	if isArchived(block) && !(hasArchived && !archived) {
+		return nil, errArchived()
+	}
+
+	blockType := block["type"].(string)
+	for key, v := range body {
+		switch key {
+		case "archived", "in_trash", "type", "object", "id":
+		case blockType:
+			update, ok := v.(object)
+			if !ok {
+				return nil, errValidation("body failed validation: body.%s should be an object.", key)
+			}
+			if children, _ := update["children"].([]any); len(children) > 0 {
+				return nil, errValidation("body failed validation: body.%s.children should be not present.", key)
+			}
+			delete(update, "children")
+
+			content, _ := block[blockType].(object)
+			if content == nil {
+				content = object{}
+				block[blockType] = content
+			}
+			normalizeBlockContent(blockType, update)
+			for field, value := range update {
+				content[field] = value
+			}
+		default:
+			return nil, errValidation("body failed validation: body.%s should be not present, the block type is %s.", key, blockType)
+		}
+	}
+
+	if hasArchived {
+		s.setObjectArchived(id, archived)
+	}
+	s.touch(block)
+
+	return block, nil
+}
+
+// validateNewBlocks checks the blocks of append (create page) request as Notion does:
+// the number of blocks, their types and the nesting levels.
+func validateNewBlocks(children []any, path string, level int) *notion.APIError {
+	if len(children) > maxAppendChildren {
+		return errValidation("body failed validation: %s.length should be ≤ `%d`, instead was `%d`.", path, maxAppendChildren, len(children))
+	}
+
+	for i, v := range children {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		input, ok := v.(object)
+		if !ok {
+			return errValidation("body failed validation: %s should be an object.", itemPath)
+		}
+		blockType := blockTypeOf(input)
+		if blockType == "" {
+			return errValidation("body failed validation: %s.type should be defined, instead was `undefined`.", itemPath)
+		}
+		if uncreatableBlockTypes[blockType] {
+			return errValidation("body failed validation: %s.%s is not supported by the API.", itemPath, blockType)
+		}
+
+		content, ok := input[blockType].(object)
+		if !ok {
+			return errValidation("body failed validation: %s.%s should be defined, instead was `undefined`.", itemPath, blockType)
+		}
+
+		nested, _ := content["children"].([]any)
+		if len(nested) == 0 {
+			continue
+		}
+		if level >= maxNestingLevels {
+			return errValidation("body failed validation: %s.%s.children should be not present, instead was `[...]`.", itemPath, blockType)
+		}
+		if apiErr := validateNewBlocks(nested, itemPath+"."+blockType+".children", level+1); apiErr != nil {
+			return apiErr
+		}
+	}
+
+	return nil
+}
+
+// blockTypeOf returns the type of the block: either explicit or the only type-specific key.
+func blockTypeOf(input object) string {
+	if blockType, ok := input["type"].(string); ok && blockType != "" {
+		return blockType
+	}
+	for key, v := range input {
+		if _, ok := v.(object); ok && key != "parent" && key != "created_by" && key != "last_edited_by" {
+			return key
+		}
+	}
+	return ""
+}
+
+// normalizeBlockContent fills the computed fields of rich texts in the block content.
+func normalizeBlockContent(blockType string, content object) {
+	for _, field := range richTextFields {
+		if v, ok := content[field]; ok && v != nil {
+			content[field] = normalizeRichTexts(v)
+		}
+	}
+
+	if blockType == "table_row" {
+		if cells, ok := content["cells"].([]any); ok {
+			for i, cell := range cells {
+				cells[i] = normalizeRichTexts(cell)
+			}
+		}
+	}
+}
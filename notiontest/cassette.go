@@ -0,0 +1,399 @@
+package notiontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode is a mode of the Recorder.
+type Mode string
+
+// nolint:revive
+const (
+	// ModeReplay replays the recorded interactions only. Unmatched requests fail.
+	ModeReplay Mode = "replay"
+	// ModeRecord sends all requests to Notion and records them, replacing the cassette.
+	ModeRecord Mode = "record"
+	// ModeRecordIfMissing replays the recorded interactions and records the missing ones.
+	ModeRecordIfMissing Mode = "record_if_missing"
+)
+
+// ParseMode parses the Mode, e.g. from an environment variable. Empty string means ModeReplay.
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(s); mode {
+	case "":
+		return ModeReplay, nil
+	case ModeReplay, ModeRecord, ModeRecordIfMissing:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown cassette mode %q", s)
+	}
+}
+
+// scrubbed replaces the scrubbed values in cassettes.
+const scrubbed = "[SCRUBBED]"
+
+// scrubbedHeaders are the headers that are never recorded as is.
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultScrubFields are JSON fields scrubbed by default.
+var DefaultScrubFields = []string{"access_token", "refresh_token"}
+
+// Cassette is a recorded list of HTTP interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded pair of HTTP request and response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	// Body is the normalized JSON body (keys are sorted, scrubbed fields are replaced).
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	// Body holds JSON bodies, BodyText holds all others (e.g. HTML pages of proxies).
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"body_text,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records HTTP interactions to a cassette file
+// and replays them, so tests can run offline and deterministically.
+//
+// Requests are matched on method, path, query and normalized JSON body. Identical requests
+// are replayed in the recorded order; once they are all used, ModeRecordIfMissing records
+// the request again. Authorization headers are never recorded;
+// configured JSON fields and values are scrubbed from both requests and responses.
+//
+// Recorded interactions are written to the file by Save.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	scrubFields  map[string]bool
+	placeholders map[string]string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	dirty    bool
+}
+
+// RecorderOption configures the Recorder.
+type RecorderOption func(*Recorder)
+
+// WithRealTransport sets the transport used for recording (http.DefaultTransport by default).
+func WithRealTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) { r.transport = transport }
+}
+
+// WithScrubFields adds JSON fields (e.g. "email") whose values are scrubbed.
+func WithScrubFields(fields ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.scrubFields[field] = true
+		}
+	}
+}
+
+// WithPlaceholder replaces every occurrence of the value (e.g. a real page ID)
+// in recorded paths, queries and bodies with the placeholder. Incoming requests are
+// matched after the same replacement, so tests can use either of them in replay mode.
+func WithPlaceholder(value, placeholder string) RecorderOption {
+	return func(r *Recorder) {
+		if value != "" && value != placeholder {
+			r.placeholders[value] = placeholder
+		}
+	}
+}
+
+// NewRecorder loads the cassette from the path (unless mode is ModeRecord) and returns a new Recorder.
+// In ModeReplay the cassette file must exist.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:         path,
+		mode:         mode,
+		transport:    http.DefaultTransport,
+		scrubFields:  map[string]bool{},
+		placeholders: map[string]string{},
+		cassette:     &Cassette{},
+	}
+	WithScrubFields(DefaultScrubFields...)(r)
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && mode == ModeRecordIfMissing:
+		return r, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	if err := json.Unmarshal(data, r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	// bodies are indented in the file, but matched compacted
+	for _, interaction := range r.cassette.Interactions {
+		if len(interaction.Request.Body) == 0 {
+			continue
+		}
+		var body bytes.Buffer
+		if err := json.Compact(&body, interaction.Request.Body); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+		interaction.Request.Body = body.Bytes()
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() Mode { return r.mode }
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode != ModeRecord {
+		if interaction := r.match(recorded); interaction != nil {
+			return interaction.Response.toResponse(req), nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("no recorded interaction for %s %s in cassette %s", recorded.Method, recorded.Path, r.path)
+		}
+	}
+
+	return r.record(req, recorded)
+}
+
+// Save writes the cassette file if anything was recorded.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o750); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	r.dirty = false
+	return nil
+}
+
+// match finds the first not used recorded interaction matching the request.
+// If all the matching interactions were used, the last one is replayed again in ModeReplay,
+// while in ModeRecordIfMissing nil is returned, so the request is recorded.
+func (r *Recorder) match(req RecordedRequest) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !interaction.Request.matches(req) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction
+		}
+		last = i
+	}
+	if last < 0 || r.mode != ModeReplay {
+		return nil
+	}
+	return r.cassette.Interactions[last]
+}
+
+// record sends the request via the real transport and records the interaction.
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(data))
+
+	response := RecordedResponse{StatusCode: res.StatusCode, Header: r.scrubHeader(res.Header)}
+	// the body is normalized, so its length may change
+	response.Header.Del("Content-Length")
+	if body, ok := r.normalizeBody(data); ok {
+		response.Body = body
+	} else {
+		response.BodyText = r.replacePlaceholders(string(data))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{Request: recorded, Response: response})
+	r.used = append(r.used, true)
+	r.dirty = true
+
+	return res, nil
+}
+
+// recordRequest converts the request into its normalized recorded form.
+// The request body is read and restored.
+func (r *Recorder) recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   r.replacePlaceholders(req.URL.Path),
+		Query:  r.replacePlaceholders(req.URL.Query().Encode()),
+		Header: r.scrubHeader(req.Header),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) > 0 {
+		body, ok := r.normalizeBody(data)
+		if !ok {
+			return recorded, fmt.Errorf("request body of %s %s is not JSON", req.Method, req.URL.Path)
+		}
+		recorded.Body = body
+	}
+
+	return recorded, nil
+}
+
+// normalizeBody re-encodes the JSON body with sorted keys, scrubbed fields and placeholders.
+func (r *Recorder) normalizeBody(data []byte) (json.RawMessage, bool) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+	v = r.scrubValue(v)
+
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return json.RawMessage(r.replacePlaceholders(string(normalized))), true
+}
+
+// scrubValue replaces values of the scrubbed fields recursively.
+func (r *Recorder) scrubValue(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if r.scrubFields[key] && item != nil {
+				value[key] = scrubbed
+				continue
+			}
+			value[key] = r.scrubValue(item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = r.scrubValue(item)
+		}
+	}
+	return v
+}
+
+// scrubHeader copies the header, scrubbing credentials.
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	result := header.Clone()
+	for _, name := range scrubbedHeaders {
+		if result.Get(name) != "" {
+			result.Set(name, scrubbed)
+		}
+	}
+	return result
+}
+
+func (r *Recorder) replacePlaceholders(s string) string {
+	for value, placeholder := range r.placeholders {
+		s = strings.ReplaceAll(s, value, placeholder)
+	}
+	return s
+}
+
+// matches returns true if the requests have the same method, path, query and body.
+func (req RecordedRequest) matches(other RecordedRequest) bool {
+	return req.Method == other.Method &&
+		req.Path == other.Path &&
+		req.Query == other.Query &&
+		bytes.Equal(req.Body, other.Body)
+}
+
+// toResponse builds a new http.Response from the recorded one.
+func (res RecordedResponse) toResponse(req *http.Request) *http.Response {
+	body := []byte(res.BodyText)
+	if len(res.Body) > 0 {
+		body = res.Body
+	}
+
+	header := res.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+		StatusCode:    res.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
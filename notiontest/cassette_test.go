@@ -0,0 +1,199 @@
+package notiontest_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func pageTitle(t *testing.T, page *notion.Page) string {
+	t.Helper()
+
+	title, ok := page.Properties["title"].(*notion.TitleProperty)
+	require.True(t, ok)
+	require.NotEmpty(t, title.Title)
+	return title.Title[0].PlainText
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	srv := notiontest.NewServer()
+	defer srv.Close()
+
+	person := srv.AddUser(notion.NewPersonUser("", "jane@example.com"))
+	page := srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewWorkspaceParent(), Properties: titleProperties("Before")})
+
+	// interactions is the scenario that is recorded and then replayed
+	interactions := func(t *testing.T, client *notion.Client) {
+		got, err := client.Pages.Get(ctx, page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Before", pageTitle(t, got))
+
+		_, err = client.Pages.Update(ctx, page.ID, &notion.PageUpdateRequest{Properties: titleProperties("After")})
+		require.NoError(t, err)
+
+		got, err = client.Pages.Get(ctx, page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "After", pageTitle(t, got))
+
+		user, err := client.Users.Get(ctx, person.ID)
+		require.NoError(t, err)
+		assert.Equal(t, person.ID, user.ID)
+	}
+
+	t.Run("should record interactions", func(t *testing.T) {
+		recorder, err := notiontest.NewRecorder(path, notiontest.ModeRecord, notiontest.WithScrubFields("email"))
+		require.NoError(t, err)
+
+		interactions(t, srv.Client(notion.WithTransport(recorder)))
+		require.NoError(t, recorder.Save())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret_notiontest")
+		assert.NotContains(t, string(data), "jane@example.com")
+		assert.Contains(t, string(data), "[SCRUBBED]")
+	})
+
+	t.Run("should replay interactions in order", func(t *testing.T) {
+		recorder, err := notiontest.NewRecorder(path, notiontest.ModeReplay,
+			notiontest.WithRealTransport(failingTransport(t)))
+		require.NoError(t, err)
+
+		client := srv.Client(notion.WithTransport(recorder))
+		interactions(t, client)
+
+		user, err := client.Users.Get(ctx, person.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "[SCRUBBED]", user.Person.Email)
+	})
+
+	t.Run("should fail on unrecorded requests in replay mode", func(t *testing.T) {
+		recorder, err := notiontest.NewRecorder(path, notiontest.ModeReplay,
+			notiontest.WithRealTransport(failingTransport(t)))
+		require.NoError(t, err)
+
+		_, err = srv.Client(notion.WithTransport(recorder)).Users.Me(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded interaction for GET /v1/users/me")
+	})
+
+	t.Run("should record missing interactions only", func(t *testing.T) {
+		recorder, err := notiontest.NewRecorder(path, notiontest.ModeRecordIfMissing)
+		require.NoError(t, err)
+		client := srv.Client(notion.WithTransport(recorder))
+
+		// the page is "After" on the server already, so this one is replayed
+		got, err := client.Pages.Get(ctx, page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Before", pageTitle(t, got))
+
+		got, err = client.Pages.Get(ctx, page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "After", pageTitle(t, got))
+
+		// all the recorded interactions of this request are used, so it's recorded
+		_, err = srv.Client().Pages.Update(ctx, page.ID, &notion.PageUpdateRequest{Properties: titleProperties("Latest")})
+		require.NoError(t, err)
+		got, err = client.Pages.Get(ctx, page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Latest", pageTitle(t, got))
+
+		me, err := client.Users.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, srv.Bot().ID, me.ID)
+		require.NoError(t, recorder.Save())
+
+		replayer, err := notiontest.NewRecorder(path, notiontest.ModeReplay,
+			notiontest.WithRealTransport(failingTransport(t)))
+		require.NoError(t, err)
+		me, err = srv.Client(notion.WithTransport(replayer)).Users.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, srv.Bot().ID, me.ID)
+	})
+
+	t.Run("should fail in replay mode without a cassette", func(t *testing.T) {
+		_, err := notiontest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), notiontest.ModeReplay)
+		require.Error(t, err)
+	})
+}
+
+func TestRecorder_Matching(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	srv := notiontest.NewServer()
+	defer srv.Close()
+
+	send := func(t *testing.T, recorder *notiontest.Recorder, query, body string) int {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/v1/search"+query, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret_notiontest")
+		req.Header.Set("Notion-Version", "2022-06-28")
+
+		res, err := recorder.RoundTrip(req)
+		if err != nil {
+			return 0
+		}
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+
+	recorder, err := notiontest.NewRecorder(path, notiontest.ModeRecord)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, send(t, recorder, "?b=2&a=1", `{"query":"x","page_size":10}`))
+	require.NoError(t, recorder.Save())
+
+	replayer, err := notiontest.NewRecorder(path, notiontest.ModeReplay, notiontest.WithRealTransport(failingTransport(t)))
+	require.NoError(t, err)
+
+	t.Run("should ignore key order and formatting", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(t, replayer, "?a=1&b=2", "{\n  \"page_size\": 10,\n  \"query\": \"x\"\n}"))
+	})
+
+	t.Run("should match query and body", func(t *testing.T) {
+		assert.Zero(t, send(t, replayer, "?a=1", `{"query":"x","page_size":10}`))
+		assert.Zero(t, send(t, replayer, "?a=1&b=2", `{"query":"y","page_size":10}`))
+	})
+}
+
+func TestParseMode(t *testing.T) {
+	for input, expected := range map[string]notiontest.Mode{
+		"":                  notiontest.ModeReplay,
+		"replay":            notiontest.ModeReplay,
+		"record":            notiontest.ModeRecord,
+		"record_if_missing": notiontest.ModeRecordIfMissing,
+	} {
+		mode, err := notiontest.ParseMode(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+
+	_, err := notiontest.ParseMode("rewind")
+	assert.Error(t, err)
+}
+
+// failingTransport fails the test if a request reaches the network.
+func failingTransport(t *testing.T) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected real request: %s %s", req.Method, req.URL)
+		return nil, http.ErrHandlerTimeout
+	})
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
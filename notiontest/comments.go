@@ -0,0 +1,90 @@
+package notiontest
+
+import (
+	"net/http"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func (s *Server) handleCreateComment(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.createComment(body)
+}
+
+func (s *Server) listComments(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.URL.Query().Get("block_id"))
+	if id == "" {
+		return nil, errValidation("block_id should be defined, instead was `undefined`.")
+	}
+	if _, ok := s.blocks[id]; !ok {
+		return nil, errNotFound(id)
+	}
+
+	params, apiErr := s.queryListParams(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	comments := make([]object, 0)
+	for _, comment := range s.comments {
+		if _, parentID := parseParent(comment["parent"]); parentID == id {
+			comments = append(comments, comment)
+		}
+	}
+
+	return paginate(comments, "comment", params)
+}
+
+// createComment adds a comment to a page (block) as a new discussion, or to an existing discussion.
+func (s *Server) createComment(body object) (object, *notion.APIError) {
+	richText, ok := body["rich_text"].([]any)
+	if !ok {
+		return nil, errValidation("body failed validation: body.rich_text should be defined, instead was `undefined`.")
+	}
+
+	var parent object
+	discussionID, _ := body["discussion_id"].(string)
+	if discussionID != "" {
+		for _, comment := range s.comments {
+			if comment["discussion_id"] == discussionID {
+				parent = comment["parent"].(object)
+				break
+			}
+		}
+		if parent == nil {
+			return nil, errNotFound(discussionID)
+		}
+	} else {
+		parentType, parentID := parseParent(body["parent"])
+		if parentType != "page_id" && parentType != "block_id" {
+			return nil, errValidation("body failed validation: body.parent or body.discussion_id should be defined.")
+		}
+		block, ok := s.blocks[parentID]
+		if !ok {
+			return nil, errNotFound(parentID)
+		}
+		if isArchived(block) {
+			return nil, errArchived()
+		}
+		parent = newParent(parentType, parentID)
+		discussionID = newID()
+	}
+
+	now := s.timestamp()
+	comment := object{
+		"object":           "comment",
+		"id":               newID(),
+		"parent":           parent,
+		"discussion_id":    discussionID,
+		"created_time":     now,
+		"last_edited_time": now,
+		"created_by":       s.userRef(),
+		"rich_text":        normalizeRichTexts(richText),
+	}
+	s.comments = append(s.comments, comment)
+
+	return comment, nil
+}
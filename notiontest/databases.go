@@ -0,0 +1,268 @@
+package notiontest
+
+import (
+	"net/http"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func (s *Server) handleCreateDatabase(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.createDatabase(body)
+}
+
+func (s *Server) getDatabase(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	db, ok := s.databases[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+	return db, nil
+}
+
+func (s *Server) handleUpdateDatabase(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.updateDatabase(normalizeID(r.PathValue("id")), body)
+}
+
+func (s *Server) queryDatabase(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	db, ok := s.databases[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	params, apiErr := s.bodyListParams(body)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	pages := make([]object, 0)
+	for _, pageID := range s.order {
+		page, ok := s.pages[pageID]
+		if !ok || isArchived(page) {
+			continue
+		}
+		if parentType, parentID := parseParent(page["parent"]); parentType != "database_id" || parentID != id {
+			continue
+		}
+
+		matched, apiErr := s.matchFilter(db, page, body["filter"])
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if matched {
+			pages = append(pages, page)
+		}
+	}
+
+	sorts, _ := body["sorts"].([]any)
+	if apiErr := sortPages(db, pages, sorts); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return paginate(pages, "page_or_database", params)
+}
+
+// createDatabase creates a database with the given schema.
+func (s *Server) createDatabase(body object) (object, *notion.APIError) {
+	parentType, parentID := parseParent(body["parent"])
+	switch parentType {
+	case "page_id":
+		parent, ok := s.pages[parentID]
+		if !ok {
+			return nil, errNotFound(parentID)
+		}
+		if isArchived(parent) {
+			return nil, errArchived()
+		}
+	case "workspace":
+	default:
+		return nil, errValidation("body failed validation: body.parent.page_id should be defined, instead was `undefined`.")
+	}
+
+	input, ok := body["properties"].(object)
+	if !ok {
+		return nil, errValidation("body failed validation: body.properties should be defined, instead was `undefined`.")
+	}
+	schema := object{}
+	for name, v := range input {
+		config, _ := v.(object)
+		property, apiErr := newPropertyConfig(name, config)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		schema[name] = property
+	}
+	if !hasTitleProperty(schema) {
+		return nil, errValidation("Title property is required, and there can be only one.")
+	}
+
+	description := body["description"]
+	if description == nil {
+		description = []any{}
+	}
+	isInline, _ := body["is_inline"].(bool)
+
+	id := newID()
+	now := s.timestamp()
+	db := object{
+		"object":           "database",
+		"id":               id,
+		"created_time":     now,
+		"last_edited_time": now,
+		"created_by":       s.userRef(),
+		"last_edited_by":   s.userRef(),
+		"title":            normalizeRichTexts(body["title"]),
+		"description":      normalizeRichTexts(description),
+		"icon":             body["icon"],
+		"cover":            body["cover"],
+		"properties":       schema,
+		"parent":           newParent(parentType, parentID),
+		"url":              objectURL(id),
+		"public_url":       nil,
+		"archived":         false,
+		"in_trash":         false,
+		"is_inline":        isInline,
+	}
+
+	s.databases[id] = db
+	s.order = append(s.order, id)
+	s.addObjectBlock(db, "child_database")
+
+	return db, nil
+}
+
+// updateDatabase updates title, description and schema of the database.
+// Schema changes (added, renamed, removed properties) are applied to the database pages too.
+func (s *Server) updateDatabase(id string, body object) (object, *notion.APIError) {
+	db, ok := s.databases[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+
+	archived, hasArchived := archivedFlag(body)
+	if isArchived(db) && !(hasArchived && !archived) {
+		return nil, errArchived()
+	}
+
+	for _, key := range []string{"title", "description"} {
+		if v, ok := body[key]; ok {
+			db[key] = normalizeRichTexts(v)
+		}
+	}
+	for _, key := range []string{"icon", "cover", "is_inline"} {
+		if v, ok := body[key]; ok {
+			db[key] = v
+		}
+	}
+
+	if input, ok := body["properties"].(object); ok {
+		if apiErr := s.updateSchema(db, input); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	if hasArchived {
+		s.setObjectArchived(id, archived)
+	}
+
+	s.touch(db)
+	if block, ok := s.blocks[id]; ok {
+		block["child_database"] = object{"title": titleOf(db)}
+		s.touch(block)
+	}
+
+	return db, nil
+}
+
+// updateSchema applies the property changes to the database schema and its pages.
+func (s *Server) updateSchema(db object, input object) *notion.APIError {
+	schema := db["properties"].(object)
+
+	// the schema is validated first, to be changed atomically
+	updated := cloneObject(schema)
+	renamed := map[string]string{}
+	removed := map[string]bool{}
+	for key, v := range input {
+		name, config := findProperty(updated, key)
+		change, _ := v.(object)
+
+		switch {
+		case v == nil && config == nil:
+			return errValidation("%s is not a property that exists.", key)
+		case v == nil:
+			if config["type"] == "title" {
+				return errValidation("Can't remove the title property.")
+			}
+			delete(updated, name)
+			removed[name] = true
+		case config == nil:
+			property, apiErr := newPropertyConfig(key, change)
+			if apiErr != nil {
+				return apiErr
+			}
+			updated[key] = property
+		default:
+			if propertyType := propertyConfigType(change); propertyType != "" && propertyType != config["type"] {
+				property, apiErr := newPropertyConfig(name, change)
+				if apiErr != nil {
+					return apiErr
+				}
+				property["id"] = config["id"]
+				config = property
+			} else if settings, ok := change[propertyType].(object); ok && propertyType != "" {
+				config[propertyType] = settings
+			}
+
+			delete(updated, name)
+			if newName, ok := change["name"].(string); ok && newName != "" && newName != name {
+				renamed[name] = newName
+				name = newName
+			}
+			config["name"] = name
+			updated[name] = config
+		}
+	}
+	if !hasTitleProperty(updated) {
+		return errValidation("Title property is required, and there can be only one.")
+	}
+	db["properties"] = updated
+
+	for _, pageID := range s.order {
+		page, ok := s.pages[pageID]
+		if !ok {
+			continue
+		}
+		if parentType, parentID := parseParent(page["parent"]); parentType != "database_id" || parentID != db["id"] {
+			continue
+		}
+
+		properties := page["properties"].(object)
+		for oldName, newName := range renamed {
+			properties[newName] = properties[oldName]
+			delete(properties, oldName)
+		}
+		for name := range removed {
+			delete(properties, name)
+		}
+		for name, v := range updated {
+			config := v.(object)
+			if value, ok := properties[name].(object); !ok || value["type"] != config["type"] {
+				properties[name] = s.emptyPropertyValue(db, page, config)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package notiontest
+
+import (
+	"strings"
+)
+
+//
+// IDs
+//
+
+// normalizeID formats an ID without dashes (as Notion accepts them) into the dashed UUID form.
+func normalizeID(id string) string {
+	if len(id) != 32 || strings.Contains(id, "-") {
+		return id
+	}
+	return id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]
+}
+
+//
+// Parents
+//
+
+// parseParent returns the type (page_id, database_id, block_id or workspace) and the ID of the parent object.
+func parseParent(v any) (string, string) {
+	parent, _ := v.(object)
+	for _, parentType := range []string{"page_id", "database_id", "block_id"} {
+		if id, ok := parent[parentType].(string); ok && id != "" {
+			return parentType, normalizeID(id)
+		}
+	}
+	if workspace, _ := parent["workspace"].(bool); workspace {
+		return "workspace", ""
+	}
+	return "", ""
+}
+
+// newParent returns a parent object of the given type.
+func newParent(parentType, id string) object {
+	if parentType == "workspace" {
+		return object{"type": "workspace", "workspace": true}
+	}
+	return object{"type": parentType, parentType: id}
+}
+
+//
+// Rich texts
+//
+
+// normalizeRichTexts fills the fields Notion computes for rich text objects
+// (type, plain_text, annotations, href) if they are missing.
+func normalizeRichTexts(v any) any {
+	items, ok := v.([]any)
+	if !ok {
+		return []any{}
+	}
+
+	for _, item := range items {
+		rt, ok := item.(object)
+		if !ok {
+			continue
+		}
+
+		if _, ok := rt["type"].(string); !ok {
+			for _, t := range []string{"text", "mention", "equation"} {
+				if _, found := rt[t]; found {
+					rt["type"] = t
+					break
+				}
+			}
+		}
+
+		if _, ok := rt["plain_text"]; !ok {
+			rt["plain_text"] = richTextPlain(rt)
+		}
+		if _, ok := rt["annotations"]; !ok {
+			rt["annotations"] = object{
+				"bold": false, "italic": false, "strikethrough": false,
+				"underline": false, "code": false, "color": "default",
+			}
+		}
+		if _, ok := rt["href"]; !ok {
+			rt["href"] = nil
+			if text, ok := rt["text"].(object); ok {
+				if link, ok := text["link"].(object); ok {
+					rt["href"] = link["url"]
+				}
+			}
+		}
+	}
+
+	return items
+}
+
+// richTextPlain returns the plain text of a single rich text object.
+func richTextPlain(rt object) string {
+	if text, ok := rt["plain_text"].(string); ok {
+		return text
+	}
+	if text, ok := rt["text"].(object); ok {
+		content, _ := text["content"].(string)
+		return content
+	}
+	if equation, ok := rt["equation"].(object); ok {
+		expression, _ := equation["expression"].(string)
+		return expression
+	}
+	return ""
+}
+
+// plainText returns the concatenated plain text of rich texts.
+func plainText(v any) string {
+	items, _ := v.([]any)
+
+	var sb strings.Builder
+	for _, item := range items {
+		if rt, ok := item.(object); ok {
+			sb.WriteString(richTextPlain(rt))
+		}
+	}
+	return sb.String()
+}
+
+// titleOf returns the plain text title of a page or database.
+func titleOf(obj object) string {
+	if title, ok := obj["title"]; ok && obj["object"] == "database" {
+		return plainText(title)
+	}
+
+	properties, _ := obj["properties"].(object)
+	for _, v := range properties {
+		if prop, ok := v.(object); ok && prop["type"] == "title" {
+			return plainText(prop["title"])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package notiontest
+
+import (
+	"net/http"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func (s *Server) handleCreatePage(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.createPage(body, false)
+}
+
+func (s *Server) getPage(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	page, ok := s.pages[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+	return page, nil
+}
+
+func (s *Server) handleUpdatePage(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.updatePage(normalizeID(r.PathValue("id")), body)
+}
+
+// createPage creates a page. Seeding skips the limits Notion applies to the children.
+func (s *Server) createPage(body object, seeding bool) (object, *notion.APIError) {
+	parentType, parentID := parseParent(body["parent"])
+	var db object
+	switch parentType {
+	case "page_id":
+		parentPage, ok := s.pages[parentID]
+		if !ok {
+			return nil, errNotFound(parentID)
+		}
+		if isArchived(parentPage) {
+			return nil, errArchived()
+		}
+	case "database_id":
+		var ok bool
+		if db, ok = s.databases[parentID]; !ok {
+			return nil, errNotFound(parentID)
+		}
+		if isArchived(db) {
+			return nil, errArchived()
+		}
+	case "workspace":
+	default:
+		return nil, errValidation("body failed validation: body.parent should be defined, instead was `undefined`.")
+	}
+
+	input, ok := body["properties"].(object)
+	if !ok {
+		return nil, errValidation("body failed validation: body.properties should be an object, instead was `%v`.", body["properties"])
+	}
+
+	children, _ := body["children"].([]any)
+	if !seeding {
+		if apiErr := validateNewBlocks(children, "body.children", 0); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	id := newID()
+	now := s.timestamp()
+	page := object{
+		"object":           "page",
+		"id":               id,
+		"created_time":     now,
+		"last_edited_time": now,
+		"created_by":       s.userRef(),
+		"last_edited_by":   s.userRef(),
+		"cover":            body["cover"],
+		"icon":             body["icon"],
+		"parent":           newParent(parentType, parentID),
+		"archived":         false,
+		"in_trash":         false,
+		"url":              objectURL(id),
+		"public_url":       nil,
+	}
+
+	properties, apiErr := s.pageProperties(db, page, input, nil)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	page["properties"] = properties
+
+	s.pages[id] = page
+	s.order = append(s.order, id)
+	s.addObjectBlock(page, "child_page")
+
+	s.insertBlocks(id, children, "")
+
+	return page, nil
+}
+
+// updatePage updates properties, icon, cover and archived state of the page.
+func (s *Server) updatePage(id string, body object) (object, *notion.APIError) {
+	page, ok := s.pages[id]
+	if !ok {
+		return nil, errNotFound(id)
+	}
+
+	archived, hasArchived := archivedFlag(body)
+	if isArchived(page) && !(hasArchived && !archived) {
+		return nil, errArchived()
+	}
+
+	if input, ok := body["properties"].(object); ok {
+		var db object
+		if parentType, parentID := parseParent(page["parent"]); parentType == "database_id" {
+			db = s.databases[parentID]
+		}
+		properties, apiErr := s.pageProperties(db, page, input, page["properties"].(object))
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		page["properties"] = properties
+	}
+
+	for _, key := range []string{"icon", "cover"} {
+		if v, ok := body[key]; ok {
+			page[key] = v
+		}
+	}
+
+	if hasArchived {
+		s.setObjectArchived(id, archived)
+	}
+
+	s.touch(page)
+	if block, ok := s.blocks[id]; ok {
+		block["child_page"] = object{"title": titleOf(page)}
+		s.touch(block)
+	}
+
+	return page, nil
+}
+
+// archivedFlag returns the value of archived (or in_trash) flag of the request.
+func archivedFlag(body object) (archived bool, ok bool) {
+	for _, key := range []string{"archived", "in_trash"} {
+		if v, found := body[key].(bool); found {
+			archived, ok = archived || v, true
+		}
+	}
+	return archived, ok
+}
+
+// setObjectArchived archives (or restores) the page, database or block with the given ID,
+// keeping the page (database) and its block in sync.
+func (s *Server) setObjectArchived(id string, archived bool) {
+	for _, obj := range []object{s.pages[id], s.databases[id], s.blocks[id]} {
+		if obj != nil {
+			setArchived(obj, archived)
+		}
+	}
+}
+
+// addObjectBlock adds the child_page (child_database) block representing the page (database).
+// If the parent is a page, the block is added to its children.
+func (s *Server) addObjectBlock(obj object, blockType string) {
+	id := obj["id"].(string)
+	block := object{
+		"object":           "block",
+		"id":               id,
+		"parent":           obj["parent"],
+		"created_time":     obj["created_time"],
+		"last_edited_time": obj["last_edited_time"],
+		"created_by":       obj["created_by"],
+		"last_edited_by":   obj["last_edited_by"],
+		"has_children":     false,
+		"archived":         false,
+		"in_trash":         false,
+		"type":             blockType,
+		blockType:          object{"title": titleOf(obj)},
+	}
+	s.blocks[id] = block
+
+	if parentType, parentID := parseParent(obj["parent"]); parentType == "page_id" || parentType == "block_id" {
+		s.children[parentID] = append(s.children[parentID], id)
+		if parent, ok := s.blocks[parentID]; ok {
+			parent["has_children"] = true
+		}
+	}
+}
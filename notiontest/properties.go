@@ -0,0 +1,284 @@
+package notiontest
+
+import (
+	"strings"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// readOnlyPropertyTypes are the property types computed by Notion.
+var readOnlyPropertyTypes = map[string]bool{
+	"created_time":     true,
+	"created_by":       true,
+	"last_edited_time": true,
+	"last_edited_by":   true,
+	"formula":          true,
+	"rollup":           true,
+	"unique_id":        true,
+	"button":           true,
+}
+
+// optionColors are colors assigned to new select options in turn.
+var optionColors = []string{"default", "gray", "brown", "orange", "yellow", "green", "blue", "purple", "pink", "red"}
+
+// pageProperties validates the input property values and merges them into existing ones.
+// Pages of databases must follow the database schema, other pages can have the title only.
+// New pages (existing == nil) get empty values of all the schema properties.
+func (s *Server) pageProperties(db object, page object, input object, existing object) (object, *notion.APIError) {
+	result := object{}
+	for k, v := range existing {
+		result[k] = v
+	}
+
+	if db == nil {
+		for key, v := range input {
+			value, _ := v.(object)
+			if key != "title" || value == nil {
+				return nil, errValidation("%s is not a property that exists.", key)
+			}
+			title, ok := value["title"].([]any)
+			if !ok {
+				return nil, errValidation("title is expected to be title.")
+			}
+			result["title"] = object{"id": "title", "type": "title", "title": normalizeRichTexts(title)}
+		}
+		if _, ok := result["title"]; !ok {
+			result["title"] = object{"id": "title", "type": "title", "title": []any{}}
+		}
+		return result, nil
+	}
+
+	schema := db["properties"].(object)
+	for key, v := range input {
+		name, config := findProperty(schema, key)
+		if config == nil {
+			return nil, errValidation("%s is not a property that exists.", key)
+		}
+
+		propertyType := config["type"].(string)
+		if readOnlyPropertyTypes[propertyType] {
+			return nil, errValidation("%s is a %s property, which can't be set.", name, propertyType)
+		}
+
+		value, _ := v.(object)
+		content, ok := value[propertyType]
+		if !ok {
+			return nil, errValidation("%s is expected to be %s.", name, propertyType)
+		}
+
+		result[name] = object{
+			"id":         config["id"],
+			"type":       propertyType,
+			propertyType: normalizePropertyValue(config, content),
+		}
+	}
+
+	if existing == nil {
+		for name, v := range schema {
+			if _, ok := result[name]; !ok {
+				result[name] = s.emptyPropertyValue(db, page, v.(object))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// findProperty finds the schema property by its name or ID.
+func findProperty(schema object, key string) (string, object) {
+	if config, ok := schema[key].(object); ok {
+		return key, config
+	}
+	for name, v := range schema {
+		if config, ok := v.(object); ok && config["id"] == key {
+			return name, config
+		}
+	}
+	return "", nil
+}
+
+// normalizePropertyValue fills the fields Notion computes for property values.
+func normalizePropertyValue(config object, content any) any {
+	propertyType := config["type"].(string)
+	switch propertyType {
+	case "title", "rich_text":
+		return normalizeRichTexts(content)
+	case "select", "status":
+		if option, ok := content.(object); ok {
+			return resolveOption(config, option, propertyType == "select")
+		}
+	case "multi_select":
+		if options, ok := content.([]any); ok {
+			for i, v := range options {
+				if option, ok := v.(object); ok {
+					options[i] = resolveOption(config, option, true)
+				}
+			}
+			return options
+		}
+	}
+	return content
+}
+
+// resolveOption fills ID and color of the option from the schema.
+// Unknown options are added to the schema (if allowed), as Notion does for selects.
+func resolveOption(config object, option object, allowNew bool) object {
+	propertyType := config["type"].(string)
+	settings, _ := config[propertyType].(object)
+	if settings == nil {
+		settings = object{}
+		config[propertyType] = settings
+	}
+	options, _ := settings["options"].([]any)
+
+	for _, v := range options {
+		known, _ := v.(object)
+		if known != nil && (known["name"] == option["name"] || (option["id"] != nil && known["id"] == option["id"])) {
+			return cloneObject(known)
+		}
+	}
+
+	if !allowNew {
+		return option
+	}
+	added := newOption(option, len(options))
+	settings["options"] = append(options, added)
+	return cloneObject(added)
+}
+
+// newOption makes a schema option with ID and color.
+func newOption(option object, index int) object {
+	added := cloneObject(option)
+	if id, _ := added["id"].(string); id == "" {
+		added["id"] = newPropertyID()
+	}
+	if color, _ := added["color"].(string); color == "" {
+		added["color"] = optionColors[index%len(optionColors)]
+	}
+	return added
+}
+
+// emptyPropertyValue returns the value a new page gets for a property that was not set.
+// Computed properties get their computed value.
+func (s *Server) emptyPropertyValue(db object, page object, config object) object {
+	propertyType := config["type"].(string)
+
+	var content any
+	switch propertyType {
+	case "title", "rich_text", "multi_select", "people", "relation", "files":
+		content = []any{}
+	case "checkbox":
+		content = false
+	case "created_time":
+		content = page["created_time"]
+	case "last_edited_time":
+		content = page["last_edited_time"]
+	case "created_by", "last_edited_by":
+		content = s.userRef()
+	case "formula":
+		content = object{"type": "string", "string": nil}
+	case "rollup":
+		function := ""
+		if settings, ok := config["rollup"].(object); ok {
+			function, _ = settings["function"].(string)
+		}
+		content = object{"type": "array", "array": []any{}, "function": function}
+	case "unique_id":
+		var prefix any
+		if settings, ok := config["unique_id"].(object); ok {
+			prefix = settings["prefix"]
+		}
+		key := db["id"].(string) + "/" + config["id"].(string)
+		s.uniqueIDs[key]++
+		content = object{"prefix": prefix, "number": s.uniqueIDs[key]}
+	case "button":
+		content = object{}
+	}
+
+	return object{"id": config["id"], "type": propertyType, propertyType: content}
+}
+
+// propertyConfigType returns the type of the property schema: either explicit
+// or the only type-specific key of the config (e.g. {"title": {}}).
+func propertyConfigType(config object) string {
+	if t, ok := config["type"].(string); ok && t != "" {
+		return t
+	}
+	for key := range config {
+		switch key {
+		case "id", "name", "type", "description":
+		default:
+			return key
+		}
+	}
+	return ""
+}
+
+// newPropertyConfig validates and normalizes a database property schema.
+func newPropertyConfig(name string, config object) (object, *notion.APIError) {
+	propertyType := propertyConfigType(config)
+	if propertyType == "" {
+		return nil, errValidation("body failed validation: body.properties.%s should be a property schema.", name)
+	}
+
+	settings, _ := config[propertyType].(object)
+	if settings == nil {
+		settings = object{}
+	}
+	if options, ok := settings["options"].([]any); ok {
+		for i, v := range options {
+			if option, ok := v.(object); ok {
+				options[i] = newOption(option, i)
+			}
+		}
+	}
+
+	id := newPropertyID()
+	if propertyType == "title" {
+		id = "title"
+	}
+
+	return object{
+		"id":         id,
+		"name":       name,
+		"type":       propertyType,
+		propertyType: settings,
+	}, nil
+}
+
+// hasTitleProperty returns true if the schema has exactly one title property.
+func hasTitleProperty(schema object) bool {
+	count := 0
+	for _, v := range schema {
+		if config, ok := v.(object); ok && config["type"] == "title" {
+			count++
+		}
+	}
+	return count == 1
+}
+
+// propertyText returns the text of a text-like property value.
+func propertyText(value object) string {
+	propertyType, _ := value["type"].(string)
+	switch propertyType {
+	case "title", "rich_text":
+		return plainText(value[propertyType])
+	case "select", "status":
+		option, _ := value[propertyType].(object)
+		name, _ := option["name"].(string)
+		return name
+	case "multi_select":
+		options, _ := value[propertyType].([]any)
+		names := make([]string, 0, len(options))
+		for _, v := range options {
+			if option, ok := v.(object); ok {
+				name, _ := option["name"].(string)
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, ",")
+	default:
+		text, _ := value[propertyType].(string)
+		return text
+	}
+}
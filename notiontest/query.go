@@ -0,0 +1,393 @@
+package notiontest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// matchFilter evaluates a database query filter against the page.
+// Compound (and/or), property and timestamp filters are supported.
+func (s *Server) matchFilter(db object, page object, v any) (bool, *notion.APIError) {
+	if v == nil {
+		return true, nil
+	}
+	filter, ok := v.(object)
+	if !ok {
+		return false, errValidation("body failed validation: body.filter should be an object.")
+	}
+
+	if filters, ok := filter["and"].([]any); ok {
+		for _, f := range filters {
+			if matched, apiErr := s.matchFilter(db, page, f); apiErr != nil || !matched {
+				return false, apiErr
+			}
+		}
+		return true, nil
+	}
+	if filters, ok := filter["or"].([]any); ok {
+		for _, f := range filters {
+			if matched, apiErr := s.matchFilter(db, page, f); apiErr != nil || matched {
+				return matched, apiErr
+			}
+		}
+		return false, nil
+	}
+
+	if timestamp, ok := filter["timestamp"].(string); ok {
+		condition, _ := filter[timestamp].(object)
+		value, _ := page[timestamp].(string)
+		return s.matchDate(value, condition)
+	}
+
+	key, _ := filter["property"].(string)
+	name, _ := findProperty(db["properties"].(object), key)
+	if name == "" {
+		return false, errValidation("Could not find property with name or id: %s", key)
+	}
+	value, _ := page["properties"].(object)[name].(object)
+
+	for conditionType, c := range filter {
+		if conditionType == "property" {
+			continue
+		}
+		condition, ok := c.(object)
+		if !ok {
+			return false, errValidation("body failed validation: body.filter.%s should be an object.", conditionType)
+		}
+		matched, apiErr := s.matchProperty(value, conditionType, condition)
+		if apiErr != nil || !matched {
+			return false, apiErr
+		}
+	}
+	return true, nil
+}
+
+// matchProperty evaluates the condition (e.g. {"contains": "foo"}) against the property value.
+func (s *Server) matchProperty(value object, conditionType string, condition object) (bool, *notion.APIError) {
+	propertyType, _ := value["type"].(string)
+	content := value[propertyType]
+
+	switch propertyType {
+	case "title", "rich_text", "url", "email", "phone_number", "select", "status":
+		return matchText(propertyText(value), condition), nil
+	case "number":
+		number, ok := content.(float64)
+		return matchNumber(number, ok, condition), nil
+	case "unique_id":
+		uniqueID, _ := content.(object)
+		number, ok := uniqueID["number"].(float64)
+		if n, isInt := uniqueID["number"].(int); isInt {
+			number, ok = float64(n), true
+		}
+		return matchNumber(number, ok, condition), nil
+	case "checkbox":
+		checked, _ := content.(bool)
+		return matchCheckbox(checked, condition), nil
+	case "date":
+		date, _ := content.(object)
+		start, _ := date["start"].(string)
+		return s.matchDate(start, condition)
+	case "created_time", "last_edited_time":
+		timestamp, _ := content.(string)
+		return s.matchDate(timestamp, condition)
+	case "multi_select", "people", "relation", "created_by", "last_edited_by", "files":
+		return matchList(listValues(propertyType, content), condition), nil
+	case "formula":
+		formula, _ := content.(object)
+		formulaType, _ := formula["type"].(string)
+		sub, _ := condition[formulaType].(object)
+		if conditionType != "formula" || sub == nil {
+			return false, errValidation("body failed validation: formula filter should be of type %s.", formulaType)
+		}
+		return s.matchProperty(object{"type": formulaType, formulaType: formula[formulaType]}, formulaType, sub)
+	}
+
+	return false, errValidation("Filtering by %s properties is not supported.", propertyType)
+}
+
+// matchText evaluates text conditions. Comparisons are case-insensitive except equals.
+func matchText(text string, condition object) bool {
+	lower := strings.ToLower(text)
+	for op, v := range condition {
+		arg, _ := v.(string)
+		argLower := strings.ToLower(arg)
+
+		var ok bool
+		switch op {
+		case "equals":
+			ok = text == arg
+		case "does_not_equal":
+			ok = text != arg
+		case "contains":
+			ok = strings.Contains(lower, argLower)
+		case "does_not_contain":
+			ok = !strings.Contains(lower, argLower)
+		case "starts_with":
+			ok = strings.HasPrefix(lower, argLower)
+		case "ends_with":
+			ok = strings.HasSuffix(lower, argLower)
+		case "is_empty":
+			ok = text == ""
+		case "is_not_empty":
+			ok = text != ""
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNumber evaluates number conditions. present is false for empty values.
+func matchNumber(number float64, present bool, condition object) bool {
+	for op, v := range condition {
+		arg, _ := v.(float64)
+
+		var ok bool
+		switch op {
+		case "equals":
+			ok = present && number == arg
+		case "does_not_equal":
+			ok = !present || number != arg
+		case "greater_than":
+			ok = present && number > arg
+		case "less_than":
+			ok = present && number < arg
+		case "greater_than_or_equal_to":
+			ok = present && number >= arg
+		case "less_than_or_equal_to":
+			ok = present && number <= arg
+		case "is_empty":
+			ok = !present
+		case "is_not_empty":
+			ok = present
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCheckbox evaluates checkbox conditions.
+func matchCheckbox(checked bool, condition object) bool {
+	for op, v := range condition {
+		arg, _ := v.(bool)
+		if (op == "equals" && checked != arg) || (op == "does_not_equal" && checked == arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// listValues returns names (of options) or IDs (of users, pages) of the list-like property value.
+func listValues(propertyType string, content any) []string {
+	items, ok := content.([]any)
+	if !ok {
+		if single, isObject := content.(object); isObject {
+			items = []any{single}
+		}
+	}
+
+	values := make([]string, 0, len(items))
+	for _, v := range items {
+		item, _ := v.(object)
+		key := "id"
+		if propertyType == "multi_select" || propertyType == "files" {
+			key = "name"
+		}
+		value, _ := item[key].(string)
+		values = append(values, value)
+	}
+	return values
+}
+
+// matchList evaluates contains-like conditions of list-like properties.
+func matchList(values []string, condition object) bool {
+	for op, v := range condition {
+		arg, _ := v.(string)
+
+		var ok bool
+		switch op {
+		case "contains":
+			ok = slices.Contains(values, arg) || slices.Contains(values, normalizeID(arg))
+		case "does_not_contain":
+			ok = !slices.Contains(values, arg) && !slices.Contains(values, normalizeID(arg))
+		case "is_empty":
+			ok = len(values) == 0
+		case "is_not_empty":
+			ok = len(values) > 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchDate evaluates date conditions. Dates are compared by days when the argument has no time.
+func (s *Server) matchDate(value string, condition object) (bool, *notion.APIError) {
+	date, present := parseDate(value)
+	now := s.now()
+
+	for op, v := range condition {
+		arg, _ := v.(string)
+		argDate, argOK := parseDate(arg)
+		switch op {
+		case "equals", "before", "after", "on_or_before", "on_or_after":
+			if !argOK {
+				return false, errValidation("body failed validation: date filter %s should be a date, instead was `%v`.", op, v)
+			}
+		}
+
+		// without time part dates are compared by days
+		left := date
+		if argOK && len(arg) == len(time.DateOnly) {
+			left = date.Truncate(24 * time.Hour)
+		}
+
+		var ok bool
+		switch op {
+		case "equals":
+			ok = present && left.Equal(argDate)
+		case "before":
+			ok = present && left.Before(argDate)
+		case "after":
+			ok = present && left.After(argDate)
+		case "on_or_before":
+			ok = present && !left.After(argDate)
+		case "on_or_after":
+			ok = present && !left.Before(argDate)
+		case "past_week":
+			ok = present && !date.After(now) && date.After(now.AddDate(0, 0, -7))
+		case "past_month":
+			ok = present && !date.After(now) && date.After(now.AddDate(0, -1, 0))
+		case "past_year":
+			ok = present && !date.After(now) && date.After(now.AddDate(-1, 0, 0))
+		case "next_week":
+			ok = present && !date.Before(now) && date.Before(now.AddDate(0, 0, 7))
+		case "next_month":
+			ok = present && !date.Before(now) && date.Before(now.AddDate(0, 1, 0))
+		case "next_year":
+			ok = present && !date.Before(now) && date.Before(now.AddDate(1, 0, 0))
+		case "is_empty":
+			ok = !present
+		case "is_not_empty":
+			ok = present
+		default:
+			return false, errValidation("body failed validation: unknown date filter condition %s.", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseDate parses dates in the formats used by Notion.
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sortPages sorts the pages by the given property or timestamp sorts.
+// Pages with empty values are always sorted last.
+func sortPages(db object, pages []object, sorts []any) *notion.APIError {
+	type sortKey struct {
+		property  string
+		timestamp string
+		desc      bool
+	}
+
+	keys := make([]sortKey, 0, len(sorts))
+	for _, v := range sorts {
+		sort, _ := v.(object)
+		key := sortKey{desc: sort["direction"] == "descending"}
+		key.timestamp, _ = sort["timestamp"].(string)
+		if property, ok := sort["property"].(string); ok && property != "" {
+			name, _ := findProperty(db["properties"].(object), property)
+			if name == "" {
+				return errValidation("Could not find sort property with name or id: %s", property)
+			}
+			key.property = name
+		} else if key.timestamp == "" {
+			return errValidation("body failed validation: body.sorts should define property or timestamp.")
+		}
+		keys = append(keys, key)
+	}
+
+	slices.SortStableFunc(pages, func(a, b object) int {
+		for _, key := range keys {
+			var va, vb string
+			if key.timestamp != "" {
+				va, _ = a[key.timestamp].(string)
+				vb, _ = b[key.timestamp].(string)
+			} else {
+				va = sortValue(a["properties"].(object)[key.property])
+				vb = sortValue(b["properties"].(object)[key.property])
+			}
+
+			switch {
+			case va == vb:
+				continue
+			case va == "":
+				return 1
+			case vb == "":
+				return -1
+			}
+
+			c := cmp.Compare(va, vb)
+			if key.desc {
+				c = -c
+			}
+			return c
+		}
+		return 0
+	})
+
+	return nil
+}
+
+// sortValue returns a string representation of the property value that sorts naturally.
+// Empty values are returned as an empty string.
+func sortValue(v any) string {
+	value, _ := v.(object)
+	propertyType, _ := value["type"].(string)
+	content := value[propertyType]
+
+	switch propertyType {
+	case "number":
+		number, ok := content.(float64)
+		if !ok {
+			return ""
+		}
+		// shifted and zero-padded, so numbers are sorted as strings
+		return fmt.Sprintf("%030.6f", number+1e15)
+	case "checkbox":
+		if checked, _ := content.(bool); checked {
+			return "1"
+		}
+		return "0"
+	case "date":
+		date, _ := content.(object)
+		start, _ := date["start"].(string)
+		return start
+	case "created_time", "last_edited_time":
+		timestamp, _ := content.(string)
+		return timestamp
+	case "unique_id":
+		uniqueID, _ := content.(object)
+		return fmt.Sprintf("%020v", uniqueID["number"])
+	default:
+		return strings.ToLower(propertyText(value))
+	}
+}
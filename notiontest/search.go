@@ -0,0 +1,73 @@
+package notiontest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func (s *Server) search(r *http.Request) (any, *notion.APIError) {
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	params, apiErr := s.bodyListParams(body)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	var objectType string
+	if filter, ok := body["filter"].(object); ok {
+		property, _ := filter["property"].(string)
+		value, _ := filter["value"].(string)
+		switch {
+		case property == "" && value == "":
+		case property != "object":
+			return nil, errValidation("body failed validation: body.filter.property should be `\"object\"`, instead was `%q`.", property)
+		case value != "page" && value != "database":
+			return nil, errValidation("body failed validation: body.filter.value should be `\"page\"` or `\"database\"`, instead was `%q`.", value)
+		default:
+			objectType = value
+		}
+	}
+
+	direction := "descending"
+	if sort, ok := body["sort"].(object); ok {
+		if timestamp, _ := sort["timestamp"].(string); timestamp != "" && timestamp != "last_edited_time" {
+			return nil, errValidation("body failed validation: body.sort.timestamp should be `\"last_edited_time\"`, instead was `%q`.", timestamp)
+		}
+		if d, _ := sort["direction"].(string); d != "" {
+			direction = d
+		}
+	}
+
+	query, _ := body["query"].(string)
+	query = strings.ToLower(query)
+
+	results := make([]object, 0)
+	for _, id := range s.order {
+		obj, ok := s.pages[id]
+		if !ok {
+			obj = s.databases[id]
+		}
+		if isArchived(obj) || (objectType != "" && obj["object"] != objectType) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(titleOf(obj)), query) {
+			continue
+		}
+		results = append(results, obj)
+	}
+
+	slices.SortStableFunc(results, func(a, b object) int {
+		c := strings.Compare(a["last_edited_time"].(string), b["last_edited_time"].(string))
+		if direction == "descending" {
+			c = -c
+		}
+		return c
+	})
+
+	return paginate(results, "page_or_database", params)
+}
@@ -0,0 +1,138 @@
+package notiontest
+
+import (
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// Seeding methods add objects to the workspace directly, bypassing HTTP.
+// They follow the same rules as the corresponding endpoints, except the limits
+// on the number and nesting of children, and panic on invalid input.
+
+// Bot returns the integration's bot user (the one returned by Users.Me).
+func (s *Server) Bot() *notion.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fromObject[*notion.User](s.bot)
+}
+
+// AddUser adds a user to the workspace. A random ID is assigned if the user has none.
+func (s *Server) AddUser(user *notion.User) *notion.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := toObject(user)
+	obj["object"] = "user"
+	if id, _ := obj["id"].(string); id == "" {
+		obj["id"] = newID()
+	}
+	if _, ok := obj["type"]; !ok {
+		obj["type"] = "person"
+	}
+	s.users = append(s.users, obj)
+
+	return fromObject[*notion.User](obj)
+}
+
+// AddPage adds a page (with its children) to the workspace.
+// Unlike Pages.Create, the page can be added at the workspace level (see notion.NewWorkspaceParent).
+func (s *Server) AddPage(req *notion.PageCreateRequest) *notion.Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, apiErr := s.createPage(toObject(req), true)
+	if apiErr != nil {
+		panic(apiErr)
+	}
+	return fromObject[*notion.Page](page)
+}
+
+// AddDatabase adds a database to the workspace.
+func (s *Server) AddDatabase(req *notion.DatabaseCreateRequest) *notion.Database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db, apiErr := s.createDatabase(toObject(req))
+	if apiErr != nil {
+		panic(apiErr)
+	}
+	return fromObject[*notion.Database](db)
+}
+
+// AddBlocks appends blocks (with their children of any depth) to the page or block.
+// Any block types can be added, including the ones the API can't create (e.g. unsupported).
+// It returns the added top-level blocks.
+func (s *Server) AddBlocks(parentID notion.BlockID, blocks ...notion.Block) notion.Blocks {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	children, _ := toObject(struct {
+		Children notion.Blocks `json:"children"`
+	}{Children: blocks})["children"].([]any)
+
+	created, apiErr := s.appendBlocks(normalizeID(parentID.String()), children, "")
+	if apiErr != nil {
+		panic(apiErr)
+	}
+	return fromObject[notion.Blocks](created)
+}
+
+// AddComment adds a comment to a page or an existing discussion.
+func (s *Server) AddComment(req *notion.CommentCreateRequest) *notion.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, apiErr := s.createComment(toObject(req))
+	if apiErr != nil {
+		panic(apiErr)
+	}
+	return fromObject[*notion.Comment](comment)
+}
+
+// Inspection methods return the current state of the workspace,
+// including archived objects. They return nil for unknown IDs.
+
+// Page returns the page with the given ID.
+func (s *Server) Page(id notion.PageID) *notion.Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, ok := s.pages[normalizeID(id.String())]
+	if !ok {
+		return nil
+	}
+	return fromObject[*notion.Page](page)
+}
+
+// Database returns the database with the given ID.
+func (s *Server) Database(id notion.DatabaseID) *notion.Database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	db, ok := s.databases[normalizeID(id.String())]
+	if !ok {
+		return nil
+	}
+	return fromObject[*notion.Database](db)
+}
+
+// Block returns the block with the given ID.
+// Pages and databases are blocks as well (child_page and child_database).
+func (s *Server) Block(id notion.BlockID) notion.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	block, ok := s.blocks[normalizeID(id.String())]
+	if !ok {
+		return nil
+	}
+	return fromObject[notion.Blocks]([]object{block})[0]
+}
+
+// Children returns the not archived children (first level only) of the page or block.
+func (s *Server) Children(id notion.BlockID) notion.Blocks {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fromObject[notion.Blocks](s.childBlocks(normalizeID(id.String())))
+}
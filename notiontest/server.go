@@ -0,0 +1,418 @@
+// Package notiontest provides an in-memory fake of the Notion API for integration tests.
+//
+//	srv, client := notiontest.New(t)
+//	page := srv.AddPage(&notion.PageCreateRequest{...})
+//
+//	got, err := client.Pages.Get(ctx, page.ID)
+//
+// The Server keeps the whole workspace in memory and implements the pages, blocks,
+// databases, search, users and comments endpoints: including validation errors,
+// archiving and cursor-based pagination. It's not a full replica of Notion:
+// formulas, rollups and relations are stored but never computed.
+package notiontest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+// object is a raw JSON object, as it's sent and received by Notion.
+type object = map[string]any
+
+const (
+	// maxPageSize is the max page_size accepted by Notion.
+	maxPageSize = 100
+	// maxAppendChildren is the max number of blocks accepted by a single append request.
+	maxAppendChildren = 100
+	// maxNestingLevels is the max nesting levels of children accepted by a single request.
+	maxNestingLevels = 2
+
+	timeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+// Server is a fake Notion API server holding an in-memory workspace.
+// It's safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	now      func() time.Time
+	pageSize int
+
+	bot   object
+	users []object
+
+	pages     map[string]object
+	databases map[string]object
+	blocks    map[string]object
+	// children holds ordered IDs of child blocks of pages and blocks.
+	children map[string][]string
+	// order holds IDs of pages and databases in the creation order.
+	order    []string
+	comments []object
+
+	uniqueIDs map[string]int
+}
+
+// Option configures the Server.
+type Option func(*Server)
+
+// WithClock overrides the clock used for created_time and last_edited_time.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) { s.now = now }
+}
+
+// WithPageSize overrides the default page size of paginated responses (100),
+// so pagination can be tested with a few objects.
+func WithPageSize(size int) Option {
+	return func(s *Server) { s.pageSize = size }
+}
+
+// NewServer starts a new Server with an empty workspace.
+// The caller should call Close when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:       time.Now,
+		pageSize:  maxPageSize,
+		pages:     map[string]object{},
+		databases: map[string]object{},
+		blocks:    map[string]object{},
+		children:  map[string][]string{},
+		uniqueIDs: map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.bot = object{
+		"object":     "user",
+		"id":         newID(),
+		"type":       "bot",
+		"name":       "notiontest",
+		"avatar_url": nil,
+		"bot": object{
+			"owner":          object{"type": "workspace", "workspace": true},
+			"workspace_name": "notiontest",
+		},
+	}
+	s.users = []object{s.bot}
+
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// New starts a new Server and returns it with a Client connected to it.
+// The Server is closed when the test finishes.
+func New(tb testing.TB, opts ...Option) (*Server, *notion.Client) {
+	tb.Helper()
+
+	s := NewServer(opts...)
+	tb.Cleanup(s.Close)
+
+	return s, s.Client()
+}
+
+// Client returns a new Client connected to the Server.
+func (s *Server) Client(opts ...notion.ClientOpt) *notion.Client {
+	return notion.New("secret_notiontest", append([]notion.ClientOpt{notion.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// handlerFunc handles a single API request. It's called with the Server locked.
+type handlerFunc func(r *http.Request) (any, *notion.APIError)
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h handlerFunc) { mux.Handle(pattern, s.handler(h)) }
+
+	handle("GET /v1/users", s.listUsers)
+	handle("GET /v1/users/me", s.getMe)
+	handle("GET /v1/users/{id}", s.getUser)
+
+	handle("POST /v1/pages", s.handleCreatePage)
+	handle("GET /v1/pages/{id}", s.getPage)
+	handle("PATCH /v1/pages/{id}", s.handleUpdatePage)
+
+	handle("GET /v1/blocks/{id}", s.getBlock)
+	handle("PATCH /v1/blocks/{id}", s.handleUpdateBlock)
+	handle("DELETE /v1/blocks/{id}", s.deleteBlock)
+	handle("GET /v1/blocks/{id}/children", s.getBlockChildren)
+	handle("PATCH /v1/blocks/{id}/children", s.handleAppendBlockChildren)
+
+	handle("POST /v1/databases", s.handleCreateDatabase)
+	handle("GET /v1/databases/{id}", s.getDatabase)
+	handle("PATCH /v1/databases/{id}", s.handleUpdateDatabase)
+	handle("POST /v1/databases/{id}/query", s.queryDatabase)
+
+	handle("POST /v1/search", s.search)
+
+	handle("POST /v1/comments", s.handleCreateComment)
+	handle("GET /v1/comments", s.listComments)
+
+	handle("/", func(*http.Request) (any, *notion.APIError) {
+		return nil, newError(http.StatusBadRequest, notion.ErrorCodeInvalidRequestURL, "Invalid request URL.")
+	})
+
+	return mux
+}
+
+// handler wraps handlerFunc with authentication and JSON encoding.
+func (s *Server) handler(h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := newID()
+		w.Header().Set("X-Request-Id", requestID)
+		w.Header().Set("Content-Type", "application/json")
+
+		data, apiErr := s.serve(r, h)
+		if apiErr != nil {
+			apiErr.Object = notion.ObjectTypeError
+			apiErr.RequestID = requestID
+			data, _ = json.Marshal(apiErr)
+			w.WriteHeader(apiErr.Status)
+		}
+		_, _ = w.Write(data)
+	})
+}
+
+// serve checks the request headers and runs the handler with the Server locked.
+// The result is marshalled under the lock as well, as it may refer to the stored objects.
+func (s *Server) serve(r *http.Request, h handlerFunc) ([]byte, *notion.APIError) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") && !strings.HasPrefix(auth, "Basic ") {
+		return nil, newError(http.StatusUnauthorized, notion.ErrorCodeUnauthorized, "API token is invalid.")
+	}
+	if r.Header.Get("Notion-Version") == "" {
+		return nil, newError(http.StatusBadRequest, notion.ErrorCodeMissingVersion,
+			"Notion-Version header failed validation: Notion-Version header should be defined, instead was `undefined`.")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, apiErr := h(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, notion.ErrorCodeInternalServer, err.Error())
+	}
+	return data, nil
+}
+
+//
+// Errors
+//
+
+func newError(status int, code notion.ErrorCode, format string, args ...any) *notion.APIError {
+	return &notion.APIError{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func errNotFound(id string) *notion.APIError {
+	return newError(http.StatusNotFound, notion.ErrorCodeObjectNotFound,
+		"Could not find object with ID: %s. Make sure the relevant pages and databases are shared with your integration.", id)
+}
+
+func errValidation(format string, args ...any) *notion.APIError {
+	return newError(http.StatusBadRequest, notion.ErrorCodeValidation, format, args...)
+}
+
+func errArchived() *notion.APIError {
+	return errValidation("Can't edit block that is archived. You must unarchive the block before editing.")
+}
+
+//
+// Requests & responses
+//
+
+// decodeBody decodes the JSON body of the request. Empty body results in an empty object.
+func decodeBody(r *http.Request) (object, *notion.APIError) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, newError(http.StatusBadRequest, notion.ErrorCodeInvalidRequest, "Failed to read the request body.")
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return object{}, nil
+	}
+
+	var body object
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, newError(http.StatusBadRequest, notion.ErrorCodeInvalidJSON, "Error parsing JSON body.")
+	}
+	if body == nil {
+		body = object{}
+	}
+	return body, nil
+}
+
+// listParams holds the pagination parameters of a request.
+type listParams struct {
+	cursor   string
+	pageSize int
+}
+
+// queryListParams reads the pagination parameters from the query string.
+func (s *Server) queryListParams(r *http.Request) (listParams, *notion.APIError) {
+	params := listParams{cursor: r.URL.Query().Get("start_cursor"), pageSize: s.pageSize}
+	if raw := r.URL.Query().Get("page_size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil {
+			return params, errValidation("page_size should be a number, instead was `%s`.", raw)
+		}
+		params.pageSize = size
+	}
+	return params, params.validate()
+}
+
+// bodyListParams reads the pagination parameters from the request body.
+func (s *Server) bodyListParams(body object) (listParams, *notion.APIError) {
+	params := listParams{pageSize: s.pageSize}
+	params.cursor, _ = body["start_cursor"].(string)
+	if size, ok := body["page_size"].(float64); ok {
+		params.pageSize = int(size)
+	}
+	return params, params.validate()
+}
+
+func (p listParams) validate() *notion.APIError {
+	if p.pageSize < 1 || p.pageSize > maxPageSize {
+		return errValidation("page_size should be between 1 and %d, instead was `%d`.", maxPageSize, p.pageSize)
+	}
+	return nil
+}
+
+// paginate returns a page of a paginated list response of the given type (e.g. "block").
+// Cursors are IDs of the first item of the next page, as in Notion.
+func paginate(items []object, listType string, params listParams) (object, *notion.APIError) {
+	start := 0
+	if params.cursor != "" {
+		start = -1
+		for i, item := range items {
+			if item["id"] == params.cursor {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return nil, errValidation("start_cursor provided is invalid: %s", params.cursor)
+		}
+	}
+
+	end := min(start+params.pageSize, len(items))
+	results := make([]object, 0, end-start)
+	results = append(results, items[start:end]...)
+
+	var nextCursor any
+	if end < len(items) {
+		nextCursor = items[end]["id"]
+	}
+
+	return object{
+		"object":      "list",
+		"results":     results,
+		"next_cursor": nextCursor,
+		"has_more":    end < len(items),
+		"type":        listType,
+		listType:      object{},
+	}, nil
+}
+
+//
+// Helpers
+//
+
+// newID returns a random UUID (v4) formatted as Notion IDs are.
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// newPropertyID returns a short random ID as Notion uses for database properties.
+func newPropertyID() string {
+	var b [3]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// timestamp returns the current time formatted as Notion does.
+func (s *Server) timestamp() string { return s.now().UTC().Format(timeFormat) }
+
+// userRef returns a reference to the integration's bot, which makes all the changes.
+func (s *Server) userRef() object { return object{"object": "user", "id": s.bot["id"]} }
+
+// touch updates the last edited time of the object.
+func (s *Server) touch(obj object) {
+	obj["last_edited_time"] = s.timestamp()
+	obj["last_edited_by"] = s.userRef()
+}
+
+// objectURL returns the Notion URL of the page or database.
+func objectURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
+
+// isArchived returns true if the object is archived or in trash.
+func isArchived(obj object) bool {
+	archived, _ := obj["archived"].(bool)
+	inTrash, _ := obj["in_trash"].(bool)
+	return archived || inTrash
+}
+
+// setArchived sets both archived and in_trash flags.
+func setArchived(obj object, archived bool) {
+	obj["archived"] = archived
+	obj["in_trash"] = archived
+}
+
+// toObject converts a value (e.g. SDK request) into a raw JSON object.
+func toObject(v any) object {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var obj object
+	if err := json.Unmarshal(data, &obj); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// fromObject converts a raw JSON value into the SDK type.
+func fromObject[T any](v any) T {
+	var result T
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// cloneObject makes a deep copy of a raw JSON object.
+func cloneObject(obj object) object {
+	if obj == nil {
+		return nil
+	}
+	return fromObject[object](obj)
+}
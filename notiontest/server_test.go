@@ -0,0 +1,383 @@
+package notiontest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func paragraph(text string, children ...notion.Block) *notion.ParagraphBlock {
+	p := notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText(text)}}
+	p.SetChildren(children)
+	return notion.NewParagraphBlock(p)
+}
+
+func titleProperties(title string) notion.Properties {
+	return notion.Properties{
+		"title": &notion.TitleProperty{Title: notion.RichTexts{notion.NewTextRichText(title)}},
+	}
+}
+
+func paragraphText(t *testing.T, block notion.Block) string {
+	t.Helper()
+
+	p, ok := block.(*notion.ParagraphBlock)
+	require.True(t, ok, "expected paragraph, got %T", block)
+	require.NotEmpty(t, p.Paragraph.RichText)
+	return p.Paragraph.RichText[0].PlainText
+}
+
+func TestServer_Pages(t *testing.T) {
+	ctx := context.Background()
+	srv, client := notiontest.New(t)
+
+	root := srv.AddPage(&notion.PageCreateRequest{
+		Parent:     notion.NewWorkspaceParent(),
+		Properties: titleProperties("Root"),
+	})
+
+	t.Run("should create and get a page", func(t *testing.T) {
+		created, err := client.Pages.Create(ctx, &notion.PageCreateRequest{
+			Parent:     notion.NewPageParent(root.ID),
+			Properties: titleProperties("Child"),
+			Children:   notion.Blocks{paragraph("Hello")},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, root.ID, created.Parent.PageID)
+
+		got, err := client.Pages.Get(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, got.ID)
+		title := got.Properties["title"].(*notion.TitleProperty)
+		assert.Equal(t, "Child", title.Title[0].PlainText)
+
+		children, err := client.Blocks.GetChildren(ctx, created.ID, nil)
+		require.NoError(t, err)
+		require.Len(t, children.Results, 1)
+		assert.Equal(t, "Hello", paragraphText(t, children.Results[0]))
+
+		// the page is a child_page block of its parent
+		rootChildren := srv.Children(root.ID)
+		require.NotEmpty(t, rootChildren)
+		assert.Equal(t, notion.BlockTypeChildPage, rootChildren[len(rootChildren)-1].GetType())
+	})
+
+	t.Run("should archive and restore a page", func(t *testing.T) {
+		page := srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewPageParent(root.ID), Properties: titleProperties("To archive")})
+
+		_, err := client.Blocks.Delete(ctx, page.ID)
+		require.NoError(t, err)
+		assert.True(t, srv.Page(page.ID).Archived)
+
+		_, err = client.Blocks.Update(ctx, page.ID, &notion.BlockUpdateRequest{})
+		assert.ErrorIs(t, err, notion.ErrValidation)
+
+		restored, err := client.Pages.Update(ctx, page.ID, &notion.PageUpdateRequest{Archived: false})
+		require.NoError(t, err)
+		assert.False(t, restored.Archived)
+	})
+
+	t.Run("should fail for unknown pages", func(t *testing.T) {
+		_, err := client.Pages.Get(ctx, "00000000-0000-4000-8000-000000000000")
+		assert.ErrorIs(t, err, notion.ErrObjectNotFound)
+	})
+
+	t.Run("should reject not existing properties", func(t *testing.T) {
+		_, err := client.Pages.Create(ctx, &notion.PageCreateRequest{
+			Parent: notion.NewPageParent(root.ID),
+			Properties: notion.Properties{
+				"Status": &notion.RichTextProperty{RichText: notion.RichTexts{notion.NewTextRichText("x")}},
+			},
+		})
+		assert.ErrorIs(t, err, notion.ErrValidation)
+	})
+}
+
+func TestServer_Blocks(t *testing.T) {
+	ctx := context.Background()
+	srv, client := notiontest.New(t, notiontest.WithPageSize(2))
+
+	page := srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewWorkspaceParent(), Properties: titleProperties("Page")})
+
+	t.Run("should append children after the given block", func(t *testing.T) {
+		appended, err := client.Blocks.AppendChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{
+			Children: notion.Blocks{paragraph("first"), paragraph("third")},
+		})
+		require.NoError(t, err)
+		require.Len(t, appended.Results, 2)
+
+		_, err = client.Blocks.AppendChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{
+			After:    appended.Results[0].GetID(),
+			Children: notion.Blocks{paragraph("second")},
+		})
+		require.NoError(t, err)
+
+		var texts []string
+		for _, b := range srv.Children(page.ID) {
+			texts = append(texts, paragraphText(t, b))
+		}
+		assert.Equal(t, []string{"first", "second", "third"}, texts)
+	})
+
+	t.Run("should paginate children", func(t *testing.T) {
+		first, err := client.Blocks.GetChildren(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Len(t, first.Results, 2)
+		assert.True(t, first.HasMore)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := client.Blocks.GetChildren(ctx, page.ID, &notion.Pagination{StartCursor: first.NextCursor})
+		require.NoError(t, err)
+		assert.Len(t, second.Results, 1)
+		assert.False(t, second.HasMore)
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("should store nested children", func(t *testing.T) {
+		parent := srv.AddBlocks(page.ID, paragraph("parent", paragraph("child", paragraph("grandchild", paragraph("deep")))))[0]
+		assert.True(t, parent.GetHasChildren())
+
+		children, err := client.Blocks.GetChildren(ctx, parent.GetID(), nil)
+		require.NoError(t, err)
+		require.Len(t, children.Results, 1)
+		assert.Equal(t, "child", paragraphText(t, children.Results[0]))
+		assert.True(t, children.Results[0].GetHasChildren())
+	})
+
+	t.Run("should reject too deep nesting", func(t *testing.T) {
+		_, err := client.Blocks.AppendChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{
+			Children: notion.Blocks{paragraph("1", paragraph("2", paragraph("3", paragraph("4"))))},
+		})
+		assert.ErrorIs(t, err, notion.ErrValidation)
+	})
+
+	t.Run("should reject more than 100 children", func(t *testing.T) {
+		blocks := make(notion.Blocks, 101)
+		for i := range blocks {
+			blocks[i] = paragraph(fmt.Sprint(i))
+		}
+		_, err := client.Blocks.AppendChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{Children: blocks})
+		assert.ErrorIs(t, err, notion.ErrValidation)
+	})
+
+	t.Run("should update and delete blocks", func(t *testing.T) {
+		block := srv.AddBlocks(page.ID, paragraph("before"))[0]
+
+		updated, err := client.Blocks.Update(ctx, block.GetID(), &notion.BlockUpdateRequest{
+			Paragraph: &notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText("after")}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "after", paragraphText(t, updated))
+
+		_, err = client.Blocks.Update(ctx, block.GetID(), &notion.BlockUpdateRequest{
+			Quote: &notion.Quote{RichText: notion.RichTexts{notion.NewTextRichText("wrong type")}},
+		})
+		assert.ErrorIs(t, err, notion.ErrValidation)
+
+		deleted, err := client.Blocks.Delete(ctx, block.GetID())
+		require.NoError(t, err)
+		assert.True(t, deleted.GetArchived())
+		for _, child := range srv.Children(page.ID) {
+			assert.NotEqual(t, block.GetID(), child.GetID())
+		}
+	})
+}
+
+func TestServer_Databases(t *testing.T) {
+	ctx := context.Background()
+	srv, client := notiontest.New(t)
+
+	page := srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewWorkspaceParent(), Properties: titleProperties("Page")})
+
+	db, err := client.Databases.Create(ctx, &notion.DatabaseCreateRequest{
+		Parent: notion.NewPageParent(page.ID),
+		Title:  notion.RichTexts{notion.NewTextRichText("Tasks")},
+		Properties: notion.PropertyConfigs{
+			"Name":  &notion.TitlePropertyConfig{Type: notion.PropertyConfigTypeTitle},
+			"Score": &notion.NumberPropertyConfig{Type: notion.PropertyConfigTypeNumber},
+			"Done":  &notion.CheckboxPropertyConfig{Type: notion.PropertyConfigTypeCheckbox},
+		},
+	})
+	require.NoError(t, err)
+	require.Contains(t, db.Properties, "Score")
+
+	for i, name := range []string{"alpha", "beta", "gamma"} {
+		_, err := client.Pages.Create(ctx, &notion.PageCreateRequest{
+			Parent: notion.NewDatabaseParent(db.ID),
+			Properties: notion.Properties{
+				"Name":  &notion.TitleProperty{Title: notion.RichTexts{notion.NewTextRichText(name)}},
+				"Score": &notion.NumberProperty{Number: float64(i + 1)},
+				"Done":  &notion.CheckboxProperty{Checkbox: i != 1},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	names := func(pages notion.Pages) []string {
+		var result []string
+		for _, p := range pages {
+			result = append(result, p.Properties["Name"].(*notion.TitleProperty).Title[0].PlainText)
+		}
+		return result
+	}
+
+	t.Run("should query with filter and sorts", func(t *testing.T) {
+		minScore := 2.0
+		res, err := client.Databases.Query(ctx, db.ID, &notion.DatabaseQueryRequest{
+			Filter: notion.AndCompoundFilter{
+				notion.PropertyFilter{Property: "Score", Number: &notion.NumberFilterCondition{GreaterThanOrEqualTo: &minScore}},
+				notion.PropertyFilter{Property: "Done", Checkbox: &notion.CheckboxFilterCondition{Equals: true}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gamma"}, names(res.Results))
+
+		res, err = client.Databases.Query(ctx, db.ID, &notion.DatabaseQueryRequest{
+			Sorts: []notion.SortObject{{Property: "Score", Direction: notion.SortOrderDESC}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gamma", "beta", "alpha"}, names(res.Results))
+	})
+
+	t.Run("should paginate query results", func(t *testing.T) {
+		res, err := client.Databases.Query(ctx, db.ID, &notion.DatabaseQueryRequest{PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alpha", "beta"}, names(res.Results))
+		require.True(t, res.HasMore)
+
+		res, err = client.Databases.Query(ctx, db.ID, &notion.DatabaseQueryRequest{PageSize: 2, StartCursor: res.NextCursor})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gamma"}, names(res.Results))
+	})
+
+	t.Run("should reject filters by unknown properties", func(t *testing.T) {
+		_, err := client.Databases.Query(ctx, db.ID, &notion.DatabaseQueryRequest{
+			Filter: notion.PropertyFilter{Property: "Unknown", Checkbox: &notion.CheckboxFilterCondition{Equals: true}},
+		})
+		assert.ErrorIs(t, err, notion.ErrValidation)
+	})
+
+	t.Run("should update the schema", func(t *testing.T) {
+		updated, err := client.Databases.Update(ctx, db.ID, &notion.DatabaseUpdateRequest{
+			Title: notion.RichTexts{notion.NewTextRichText("Renamed")},
+			Properties: notion.PropertyConfigs{
+				"Notes": &notion.RichTextPropertyConfig{Type: notion.PropertyConfigTypeRichText},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", updated.Title[0].PlainText)
+		assert.Contains(t, updated.Properties, "Notes")
+
+		res, err := client.Databases.Query(ctx, db.ID, &notion.DatabaseQueryRequest{})
+		require.NoError(t, err)
+		for _, p := range res.Results {
+			assert.Contains(t, p.Properties, "Notes")
+		}
+	})
+}
+
+func TestServer_Search(t *testing.T) {
+	ctx := context.Background()
+	srv, client := notiontest.New(t)
+
+	page := srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewWorkspaceParent(), Properties: titleProperties("Meeting notes")})
+	srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewPageParent(page.ID), Properties: titleProperties("Roadmap")})
+	srv.AddDatabase(&notion.DatabaseCreateRequest{
+		Parent:     notion.NewPageParent(page.ID),
+		Title:      notion.RichTexts{notion.NewTextRichText("Meetings")},
+		Properties: notion.PropertyConfigs{"Name": &notion.TitlePropertyConfig{Type: notion.PropertyConfigTypeTitle}},
+	})
+
+	t.Run("should search by title", func(t *testing.T) {
+		res, err := client.Search.Do(ctx, &notion.SearchRequest{Query: "meeting"})
+		require.NoError(t, err)
+		assert.Len(t, res.Results, 2)
+	})
+
+	t.Run("should filter by object type", func(t *testing.T) {
+		res, err := client.Search.Do(ctx, &notion.SearchRequest{
+			Query:  "meeting",
+			Filter: notion.SearchFilter{Property: "object", Value: "database"},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, notion.ObjectTypeDatabase, res.Results[0].GetObject())
+	})
+}
+
+func TestServer_UsersAndComments(t *testing.T) {
+	ctx := context.Background()
+	srv, client := notiontest.New(t)
+
+	person := srv.AddUser(notion.NewPersonUser("", "jane@example.com"))
+	page := srv.AddPage(&notion.PageCreateRequest{Parent: notion.NewWorkspaceParent(), Properties: titleProperties("Page")})
+
+	t.Run("should list and get users", func(t *testing.T) {
+		me, err := client.Users.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, srv.Bot().ID, me.ID)
+		assert.True(t, me.IsBot())
+
+		list, err := client.Users.List(ctx, nil)
+		require.NoError(t, err)
+		assert.Len(t, list.Results, 2)
+
+		got, err := client.Users.Get(ctx, person.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.com", got.Person.Email)
+	})
+
+	t.Run("should create comments in discussions", func(t *testing.T) {
+		first, err := client.Comments.Create(ctx, &notion.CommentCreateRequest{
+			Parent:   notion.NewPageParent(page.ID),
+			RichText: notion.RichTexts{notion.NewTextRichText("First")},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Comments.Create(ctx, &notion.CommentCreateRequest{
+			DiscussionID: first.DiscussionID,
+			RichText:     notion.RichTexts{notion.NewTextRichText("Reply")},
+		})
+		require.NoError(t, err)
+
+		res, err := client.Comments.Get(ctx, page.ID, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Results, 2)
+		assert.Equal(t, first.DiscussionID, res.Results[1].DiscussionID)
+	})
+}
+
+func TestServer_Errors(t *testing.T) {
+	srv := notiontest.NewServer()
+	defer srv.Close()
+
+	t.Run("should require the Notion-Version header", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/v1/users/me", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.NotEmpty(t, res.Header.Get("X-Request-Id"))
+	})
+
+	t.Run("should respond with API errors", func(t *testing.T) {
+		var info notion.ResponseInfo
+		_, err := srv.Client().Blocks.Get(notion.ContextWithResponseInfo(context.Background(), &info), "unknown")
+
+		var apiErr *notion.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, notion.ErrorCodeObjectNotFound, apiErr.Code)
+		assert.Equal(t, info.RequestID, apiErr.RequestID)
+	})
+}
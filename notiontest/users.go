@@ -0,0 +1,29 @@
+package notiontest
+
+import (
+	"net/http"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func (s *Server) listUsers(r *http.Request) (any, *notion.APIError) {
+	params, apiErr := s.queryListParams(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return paginate(s.users, "user", params)
+}
+
+func (s *Server) getMe(*http.Request) (any, *notion.APIError) {
+	return s.bot, nil
+}
+
+func (s *Server) getUser(r *http.Request) (any, *notion.APIError) {
+	id := normalizeID(r.PathValue("id"))
+	for _, user := range s.users {
+		if user["id"] == id {
+			return user, nil
+		}
+	}
+	return nil, errNotFound(id)
+}
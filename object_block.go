@@ -3,6 +3,8 @@ package notion
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 )
 
@@ -107,14 +109,14 @@ type Blocks []Block
 // see decodeBlock() for more details
 func (b *Blocks) UnmarshalJSON(data []byte) error {
 	var err error
-	mapArr := make([]map[string]any, 0)
-	if err = json.Unmarshal(data, &mapArr); err != nil {
+	rawArr := make([]json.RawMessage, 0)
+	if err = json.Unmarshal(data, &rawArr); err != nil {
 		return err
 	}
 
-	result := make([]Block, len(mapArr))
-	for i, prop := range mapArr {
-		if result[i], err = decodeBlock(prop); err != nil {
+	result := make([]Block, len(rawArr))
+	for i, raw := range rawArr {
+		if result[i], err = decodeBlock(raw); err != nil {
 			return err
 		}
 	}
@@ -178,35 +180,72 @@ var _ HierarchicalBlock = (*BasicBlock)(nil)
 
 var _ Block = (*BasicBlock)(nil)
 
-// Note: we do not care about the order of registration and obout concurrency
-// TODO do we?
-var blockConstructors = map[BlockType]func() Block{}
+// blockRegistry holds the constructors of the block types (see RegisterBlockType).
+var blockRegistry = struct {
+	sync.RWMutex
+	constructors map[BlockType]func() Block
+}{constructors: map[BlockType]func() Block{}}
+
+// RegisterBlockType registers the constructor of the block type used to decode blocks of the type,
+// so new Notion block types can be supported without changes to the library.
+// The constructor must return a pointer to a new block struct embedding BasicBlock.
+// Registering a type again replaces its constructor. It's safe for concurrent use.
+func RegisterBlockType(blockType BlockType, constructor func() Block) {
+	blockRegistry.Lock()
+	defer blockRegistry.Unlock()
+
+	blockRegistry.constructors[blockType] = constructor
+}
+
+// RegisteredBlockTypes returns the registered block types sorted by name.
+func RegisteredBlockTypes() []BlockType {
+	blockRegistry.RLock()
+	defer blockRegistry.RUnlock()
+
+	types := make([]BlockType, 0, len(blockRegistry.constructors))
+	for blockType := range blockRegistry.constructors {
+		types = append(types, blockType)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// blockConstructor returns the constructor of the registered block type.
+func blockConstructor(blockType BlockType) (func() Block, bool) {
+	blockRegistry.RLock()
+	defer blockRegistry.RUnlock()
 
-func registerBlockDecoder(blockType BlockType, blockConstructor func() Block) {
-	blockConstructors[blockType] = blockConstructor
+	constructor, ok := blockRegistry.constructors[blockType]
+	return constructor, ok
 }
 
-func decodeBlock(raw map[string]any) (Block, error) {
-	blockType, ok := raw["type"].(string)
-	if !ok {
+// decodeBlock decodes the JSON of a block into the struct of the registered block type
+// (UnsupportedBlock for unknown types), peeking at the type first.
+func decodeBlock(data []byte) (Block, error) {
+	var head struct {
+		Type *BlockType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	if head.Type == nil {
 		return nil, fmt.Errorf("invalid block type")
 	}
 
-	constructor, found := blockConstructors[BlockType(blockType)]
+	constructor, found := blockConstructor(*head.Type)
 	if !found {
 		constructor = func() Block { return &UnsupportedBlock{} } // Default to UnsupportedBlock
 	}
 
 	// Create the block
 	block := constructor()
-
-	j, err := json.Marshal(raw)
-	if err != nil {
+	if err := json.Unmarshal(data, block); err != nil {
 		return nil, err
 	}
 
-	if err = json.Unmarshal(j, block); err != nil {
-		return nil, err
+	// keep the original JSON of the blocks we can't decode, so it survives a round trip
+	if unsupported, ok := block.(*UnsupportedBlock); ok {
+		unsupported.Raw = slices.Clone(data)
 	}
 
 	return block, nil
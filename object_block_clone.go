@@ -0,0 +1,146 @@
+package notion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrHostedFile is matched by HostedFilesError.
+var ErrHostedFile = errors.New("file is hosted by Notion")
+
+// HostedFilesError is returned by CloneForCreate for the blocks with files uploaded to Notion,
+// as such files can't be re-attached to new blocks by the API.
+type HostedFilesError struct {
+	// Blocks are the source blocks with the hosted files.
+	Blocks Blocks
+}
+
+// Error returns the list of the blocks with the hosted files.
+func (e *HostedFilesError) Error() string {
+	blocks := make([]string, len(e.Blocks))
+	for i, block := range e.Blocks {
+		blocks[i] = fmt.Sprintf("%s %s", block.GetType(), block.GetID())
+	}
+	return fmt.Sprintf("%s: %s", ErrHostedFile, strings.Join(blocks, ", "))
+}
+
+// Is returns true for ErrHostedFile.
+func (e *HostedFilesError) Is(target error) bool { return target == ErrHostedFile }
+
+// CloneOptions configures CloneForCreate.
+type CloneOptions struct {
+	// ExternalizeFiles converts the files uploaded to Notion to external files with their current URLs.
+	// The URLs of hosted files expire in an hour, so the files of such clones stop loading after that.
+	// By default, the hosted files are reported with a HostedFilesError.
+	ExternalizeFiles bool
+}
+
+// CloneForCreate returns a deep copy of the block with its children that can be created
+// (e.g. appended to another page): the fields assigned by Notion (ID, parent, timestamps, authors,
+// archived flags) are cleared. HasChildren is set from the copied children, as the constructors do.
+// The children of synced references belong to the original synced block, so they are not copied.
+//
+// Note that some blocks (e.g. child pages) can't be created by the API at all, see IsCopyable.
+func CloneForCreate(block Block, opts *CloneOptions) (Block, error) {
+	clones, err := Blocks{block}.CloneForCreate(opts)
+	if err != nil {
+		return nil, err
+	}
+	return clones[0], nil
+}
+
+// CloneForCreate returns deep copies of the blocks that can be created. See CloneForCreate for the details.
+func (b Blocks) CloneForCreate(opts *CloneOptions) (Blocks, error) {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	var clones Blocks
+	if err := json.Unmarshal(data, &clones); err != nil {
+		return nil, err
+	}
+
+	c := &blockCloner{externalize: opts.ExternalizeFiles}
+	if err := c.reset(b, clones); err != nil {
+		return nil, err
+	}
+	if len(c.hosted) > 0 {
+		return nil, &HostedFilesError{Blocks: c.hosted}
+	}
+
+	return clones, nil
+}
+
+// blockCloner holds the state of a single CloneForCreate call.
+type blockCloner struct {
+	externalize bool
+	hosted      Blocks
+}
+
+// reset clears the fields assigned by Notion from the clones of the source blocks (recursively).
+func (c *blockCloner) reset(sources, clones Blocks) error {
+	for i, clone := range clones {
+		holder, ok := clone.(BasicBlockHolder)
+		if !ok {
+			return fmt.Errorf("block type %s can't be cloned", clone.GetType())
+		}
+
+		children := childrenOf(clone)
+		clone = holder.SetBasicBlock(NewBasicBlock(clone.GetType()))
+		clones[i] = clone
+
+		if file := hostedFile(clone); file != nil {
+			if !c.externalize {
+				c.hosted = append(c.hosted, sources[i])
+			} else {
+				file.Type = FileTypeExternal
+				file.External = &FileData{URL: file.File.URL}
+				file.File = nil
+			}
+		}
+
+		if len(children) == 0 {
+			continue
+		}
+		if isSyncedReference(clone) {
+			clone.(HierarchicalBlock).SetChildren(nil)
+			continue
+		}
+		if err := c.reset(childrenOf(sources[i]), children); err != nil {
+			return err
+		}
+		clone.(HierarchicalBlock).SetChildren(children)
+	}
+
+	return nil
+}
+
+// hostedFile returns the file of the media block if it's uploaded to Notion.
+func hostedFile(block Block) *File {
+	var file *File
+	switch b := block.(type) {
+	case *ImageBlock:
+		file = &b.Image
+	case *VideoBlock:
+		file = &b.Video
+	case *AudioBlock:
+		file = &b.Audio
+	case *FileBlock:
+		file = &b.File
+	case *PdfBlock:
+		file = &b.Pdf
+	default:
+		return nil
+	}
+
+	if file.Type != FileTypeFile || file.File == nil {
+		return nil
+	}
+	return file
+}
@@ -0,0 +1,90 @@
+package notion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func TestCloneForCreate(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	template := newTestPage(t, srv, "Template")
+	srv.AddBlocks(template.ID,
+		toggle("a", toggle("b", toggle("c"))),
+		notion.NewImageBlock(notion.File{
+			Type: notion.FileTypeFile,
+			File: &notion.FileData{URL: "https://files.notion.so/image.png"},
+		}),
+		toggle("d"),
+	)
+	source, err := client.Blocks.GetTree(ctx, template.ID, nil)
+	require.NoError(t, err)
+
+	t.Run("should report hosted files", func(t *testing.T) {
+		_, err := source.CloneForCreate(nil)
+		require.ErrorIs(t, err, notion.ErrHostedFile)
+
+		var hostedErr *notion.HostedFilesError
+		require.ErrorAs(t, err, &hostedErr)
+		require.Len(t, hostedErr.Blocks, 1)
+		assert.Equal(t, source[1].GetID(), hostedErr.Blocks[0].GetID())
+	})
+
+	t.Run("should clone the tree without the server fields", func(t *testing.T) {
+		clones, err := source.CloneForCreate(&notion.CloneOptions{ExternalizeFiles: true})
+		require.NoError(t, err)
+		require.Equal(t, outline(source, ""), outline(clones, ""))
+
+		var walk func(notion.Blocks)
+		walk = func(blocks notion.Blocks) {
+			for _, block := range blocks {
+				assert.Empty(t, block.GetID())
+				assert.Zero(t, block.GetParent())
+				assert.Nil(t, block.GetCreatedTime())
+				assert.Nil(t, block.GetCreatedBy())
+				assert.Nil(t, block.GetLastEditedTime())
+				assert.Nil(t, block.GetLastEditedBy())
+				assert.Equal(t, block.(notion.HierarchicalBlock).ChildCount() > 0, block.GetHasChildren())
+				walk(block.(notion.HierarchicalBlock).GetChildren())
+			}
+		}
+		walk(clones)
+
+		image := clones[1].(*notion.ImageBlock).Image
+		assert.Equal(t, notion.FileTypeExternal, image.Type)
+		assert.Nil(t, image.File)
+		assert.Equal(t, "https://files.notion.so/image.png", image.External.URL)
+
+		// the source is not changed
+		assert.Equal(t, notion.FileTypeFile, source[1].(*notion.ImageBlock).Image.Type)
+		assert.NotEmpty(t, source[0].GetID())
+
+		page := newTestPage(t, srv, "Copy")
+		_, err = client.Blocks.AppendTree(ctx, page.ID, clones, nil)
+		require.NoError(t, err)
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, outline(source, ""), outline(tree, ""))
+	})
+
+	t.Run("should not copy children of synced references", func(t *testing.T) {
+		reference := notion.NewSyncedBlock(notion.Synced{
+			SyncedFrom: &notion.SyncedFrom{BlockID: "original"},
+		})
+		reference.SetChildren(notion.Blocks{toggle("synced")})
+
+		clone, err := notion.CloneForCreate(reference, nil)
+		require.NoError(t, err)
+		assert.Equal(t, notion.BlockID("original"), clone.(*notion.SyncedBlock).Synced.SyncedFrom.BlockID)
+		assert.Zero(t, clone.(notion.HierarchicalBlock).ChildCount())
+		assert.False(t, clone.GetHasChildren())
+	})
+}
@@ -0,0 +1,147 @@
+package notion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// EqualContent reports whether the blocks have the same content with the children of any depth.
+// IDs, parents, timestamps, authors and other fields of BasicBlock are ignored,
+// as well as the fields computed by Notion (plain texts, links, expiry times) and default values.
+// Blocks that can't be marshalled are never equal.
+func EqualContent(a, b Block) bool {
+	treeA, err := canonicalTree(a)
+	if err != nil {
+		return false
+	}
+	treeB, err := canonicalTree(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(treeA, treeB)
+}
+
+// ContentHash returns the hex-encoded SHA-256 hash of the block content with the children of any depth.
+// Blocks with equal content (see EqualContent) have the same hash.
+func ContentHash(block Block) (string, error) {
+	tree, err := canonicalTree(block)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalTree returns the type, canonical content and canonical children of the block.
+func canonicalTree(block Block) (map[string]any, error) {
+	tree := map[string]any{"type": block.GetType().String()}
+	content, err := canonicalContent(block)
+	if err != nil {
+		return nil, err
+	}
+	if content != nil {
+		tree["content"] = content
+	}
+
+	if children := childrenOf(block); len(children) > 0 {
+		trees := make([]any, len(children))
+		for i, child := range children {
+			if trees[i], err = canonicalTree(child); err != nil {
+				return nil, err
+			}
+		}
+		tree["children"] = trees
+	}
+
+	return tree, nil
+}
+
+// blockContent returns the type-specific content of the block without children.
+func blockContent(block Block) (map[string]any, error) {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	content, _ := raw[string(block.GetType())].(map[string]any)
+	delete(content, "children")
+	return content, nil
+}
+
+// computedContentFields are the fields of block content filled by Notion.
+var computedContentFields = map[string]bool{"plain_text": true, "href": true, "expiry_time": true}
+
+// equalBlockContent reports whether the blocks have the same type-specific content (children aside).
+// Fields computed by Notion and default values are ignored. Blocks that can't be marshalled are never equal.
+func equalBlockContent(a, b Block) bool {
+	if a.GetType() != b.GetType() {
+		return false
+	}
+	contentA, err := canonicalContent(a)
+	if err != nil {
+		return false
+	}
+	contentB, err := canonicalContent(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(contentA, contentB)
+}
+
+// canonicalContent returns the block content without computed fields and default values.
+func canonicalContent(block Block) (any, error) {
+	content, err := blockContent(block)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalValue(content), nil
+}
+
+// canonicalValue drops the computed fields, nulls, empty values and default colors and annotations.
+// The type of rich texts is dropped too, as it's optional and implied by their text, mention or equation object.
+func canonicalValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		richText := isRichTextObject(v)
+		result := make(map[string]any, len(v))
+		for key, value := range v {
+			if computedContentFields[key] || (key == "color" && value == string(ColorDefault)) ||
+				(richText && key == "type") {
+				continue
+			}
+			if value = canonicalValue(value); value != nil && value != false {
+				result[key] = value
+			}
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		return result
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		result := make([]any, len(v))
+		for i, value := range v {
+			result[i] = canonicalValue(value)
+		}
+		return result
+	case string:
+		if v == "" {
+			return nil
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,114 @@
+package notion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+)
+
+func TestEqualContent(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := &notion.User{AtomObject: notion.AtomObject{Object: notion.ObjectTypeUser}}
+
+	paragraph := func(text string, color notion.Color, children ...notion.Block) notion.Block {
+		p := notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText(text)}, Color: color}
+		p.SetChildren(children)
+		return notion.NewParagraphBlock(p)
+	}
+	fetched := func(block notion.Block, id notion.BlockID) notion.Block {
+		return decorateTestBasicBlock(block, id, &timestamp, user)
+	}
+
+	base := paragraph("text", "", toggle("a", toggle("b")))
+
+	tests := []struct {
+		name  string
+		other notion.Block
+		equal bool
+	}{
+		{
+			name:  "same content",
+			other: paragraph("text", "", toggle("a", toggle("b"))),
+			equal: true,
+		},
+		{
+			name:  "ignores IDs, timestamps and authors",
+			other: fetched(paragraph("text", "", fetched(toggle("a", fetched(toggle("b"), "3")), "2")), "1"),
+			equal: true,
+		},
+		{
+			name:  "ignores default color",
+			other: paragraph("text", notion.ColorDefault, toggle("a", toggle("b"))),
+			equal: true,
+		},
+		{
+			name: "ignores rich text type",
+			other: func() notion.Block {
+				p := notion.Paragraph{RichText: notion.RichTexts{{Text: &notion.Text{Content: "text"}}}}
+				p.SetChildren(notion.Blocks{toggle("a", toggle("b"))})
+				return notion.NewParagraphBlock(p)
+			}(),
+			equal: true,
+		},
+		{
+			name:  "different text",
+			other: paragraph("other", "", toggle("a", toggle("b"))),
+		},
+		{
+			name:  "different color",
+			other: paragraph("text", notion.ColorRed, toggle("a", toggle("b"))),
+		},
+		{
+			name:  "different nested child",
+			other: paragraph("text", "", toggle("a", toggle("c"))),
+		},
+		{
+			name:  "missing children",
+			other: paragraph("text", ""),
+		},
+		{
+			name:  "different type",
+			other: notion.NewQuoteBlock(notion.Quote{RichText: notion.RichTexts{notion.NewTextRichText("text")}}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, notion.EqualContent(base, tt.other))
+			baseHash, err := notion.ContentHash(base)
+			require.NoError(t, err)
+			otherHash, err := notion.ContentHash(tt.other)
+			require.NoError(t, err)
+			assert.Equal(t, tt.equal, baseHash == otherHash)
+		})
+	}
+
+	t.Run("blocks that can't be marshalled are not equal", func(t *testing.T) {
+		broken := notion.NewUnsupportedBlock()
+		broken.Raw = []byte("not json")
+
+		assert.False(t, notion.EqualContent(broken, broken))
+		assert.False(t, notion.EqualContent(base, paragraph("text", "", broken)))
+		_, err := notion.ContentHash(broken)
+		assert.Error(t, err)
+	})
+}
+
+func TestContentHash(t *testing.T) {
+	block := notion.NewParagraphBlock(notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText("hello")}})
+
+	hash, err := notion.ContentHash(block)
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+	// sha256 of {"content":{"rich_text":[{"text":{"content":"hello"}}]},"type":"paragraph"}
+	assert.Equal(t, "ba77ecd0160143ceb58f592e81e0fc22416db6f5c68542c7751d7acfb67d6327", hash)
+
+	untyped := notion.NewParagraphBlock(notion.Paragraph{RichText: notion.RichTexts{{Text: &notion.Text{Content: "hello"}}}})
+	untypedHash, err := notion.ContentHash(untyped)
+	require.NoError(t, err)
+	assert.Equal(t, hash, untypedHash, "the rich text type is implied by its text object")
+}
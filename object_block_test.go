@@ -3,11 +3,13 @@ package notion_test
 import (
 	"encoding/json"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	notion "github.com/amberpixels/notion-sdk-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBlocksUnmarshal(t *testing.T) {
@@ -104,3 +106,93 @@ func TestBlocksUnmarshal(t *testing.T) {
 		}
 	})
 }
+
+// calloutButton is a custom block type registered by TestRegisterBlockType.
+type calloutButton struct {
+	notion.BasicBlock
+	CalloutButton struct {
+		Label string `json:"label"`
+	} `json:"callout_button"`
+}
+
+func (b *calloutButton) SetBasicBlock(block notion.BasicBlock) notion.Block {
+	b.BasicBlock = block
+	return b
+}
+
+func TestRegisterBlockType(t *testing.T) {
+	const blockType notion.BlockType = "callout_button"
+	data := []byte(`[{"object":"block","id":"block1","type":"callout_button","callout_button":{"label":"Click"}}]`)
+
+	var before notion.Blocks
+	require.NoError(t, json.Unmarshal(data, &before))
+	require.IsType(t, &notion.UnsupportedBlock{}, before[0])
+	assert.NotContains(t, notion.RegisteredBlockTypes(), blockType)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			notion.RegisterBlockType(blockType, func() notion.Block { return &calloutButton{} })
+		}()
+		go func() {
+			defer wg.Done()
+			var blocks notion.Blocks
+			assert.NoError(t, json.Unmarshal(data, &blocks))
+		}()
+	}
+	wg.Wait()
+
+	var after notion.Blocks
+	require.NoError(t, json.Unmarshal(data, &after))
+	require.IsType(t, &calloutButton{}, after[0])
+	assert.Equal(t, "Click", after[0].(*calloutButton).CalloutButton.Label)
+	assert.Contains(t, notion.RegisteredBlockTypes(), blockType)
+	assert.Contains(t, notion.RegisteredBlockTypes(), notion.BlockTypeParagraph)
+}
+
+func TestUnsupportedBlock_MarshalJSON(t *testing.T) {
+	data := []byte(`[{
+		"object": "block",
+		"id": "block1",
+		"type": "ai_block",
+		"ai_block": {"prompt": "Summarize", "nested": {"values": [1, 2]}},
+		"has_children": false,
+		"archived": false
+	}]`)
+
+	var blocks notion.Blocks
+	require.NoError(t, json.Unmarshal(data, &blocks))
+	block, ok := blocks[0].(*notion.UnsupportedBlock)
+	require.True(t, ok)
+	assert.Equal(t, notion.BlockType("ai_block"), block.GetType())
+	assert.JSONEq(t, `{"prompt": "Summarize", "nested": {"values": [1, 2]}}`, string(block.Content()))
+
+	block.Archived = true
+	got, err := json.Marshal(blocks)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"object": "block",
+		"id": "block1",
+		"parent": {},
+		"type": "ai_block",
+		"ai_block": {"prompt": "Summarize", "nested": {"values": [1, 2]}},
+		"last_edited_time": null,
+		"last_edited_by": null,
+		"archived": true,
+		"in_trash": false
+	}]`, string(got))
+
+	req, err := notion.NewBlockUpdateRequest(block)
+	require.NoError(t, err)
+	assert.Contains(t, req.Extra, "ai_block")
+}
+
+func BenchmarkBlocksUnmarshal(b *testing.B) {
+	benchmarkUnmarshal[notion.Blocks](b, "testdata/block_array_unmarshal.json")
+}
+
+func BenchmarkAppendBlockChildrenResponseUnmarshal(b *testing.B) {
+	benchmarkUnmarshal[notion.AppendBlockChildrenResponse](b, "testdata/block_append_children.json")
+}
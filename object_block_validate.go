@@ -0,0 +1,206 @@
+package notion
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"unicode/utf8"
+)
+
+// Limits of rich text objects.
+//
+// See https://developers.notion.com/reference/request-limits#limits-for-property-values
+const (
+	// maxRichTextLength is the maximum length of text.content of a rich text object.
+	maxRichTextLength = 2000
+	// maxRichTexts is the maximum number of rich text objects in a single array.
+	maxRichTexts = 100
+)
+
+// ValidationIssue is a violation of the Notion constraints found by ValidateBlocks.
+type ValidationIssue struct {
+	// Path is the path to the offending value in the JSON of the blocks as Notion reports it,
+	// e.g. "[1].toggle.children[0].paragraph.rich_text[2].text.content".
+	Path string
+	// Block is the offending block (or the block holding the offending value).
+	Block Block
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the path and the message of the issue.
+func (i ValidationIssue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// ValidateBlocks checks the blocks (with their children of any depth) against the constraints
+// Notion validates at request time:
+//   - column lists have at least 2 children, all of them columns, and columns are in column lists;
+//   - tables have a positive width, and their children are rows with TableWidth cells;
+//   - there are at most 100 children on each level;
+//   - rich text contents have at most 2000 characters, and rich text arrays have at most 100 items;
+//   - bookmarks, embeds and external files have valid http(s) URLs;
+//   - there are no deprecated template blocks.
+//
+// It returns nil if the blocks are valid.
+func ValidateBlocks(blocks Blocks) []ValidationIssue {
+	v := &blockValidator{}
+	v.validateChildren("", nil, blocks)
+	return v.issues
+}
+
+// blockValidator holds the state of a single ValidateBlocks call.
+type blockValidator struct {
+	issues []ValidationIssue
+}
+
+// report adds an issue.
+func (v *blockValidator) report(path string, block Block, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{Path: path, Block: block, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateChildren validates the children at the path of the parent block (nil and "" for the top level).
+func (v *blockValidator) validateChildren(path string, parent Block, children Blocks) {
+	if len(children) > maxAppendChildren {
+		v.report(path, parent, "should have at most %d children, instead has %d", maxAppendChildren, len(children))
+	}
+
+	for i, child := range children {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		if child.GetType() == BlockTypeColumn && (parent == nil || parent.GetType() != BlockTypeColumnList) {
+			v.report(childPath, child, "column should be a child of column_list")
+		}
+		v.validateBlock(childPath, child)
+	}
+}
+
+// validateBlock validates the block at the path and its children.
+func (v *blockValidator) validateBlock(path string, block Block) {
+	contentPath := path + "." + block.GetType().String()
+	children := childrenOf(block)
+
+	switch b := block.(type) {
+	case *TemplateBlock:
+		v.report(path, block, "template blocks are deprecated and can't be created")
+	case *ColumnListBlock:
+		if len(children) < 2 {
+			v.report(path, block, "column_list should have at least 2 columns, instead has %d", len(children))
+		}
+		for i, child := range children {
+			if child.GetType() != BlockTypeColumn {
+				v.report(fmt.Sprintf("%s.children[%d]", contentPath, i), child,
+					"column_list children should be column, instead was %s", child.GetType())
+			}
+		}
+	case *TableBlock:
+		if b.Table.TableWidth <= 0 {
+			v.report(contentPath+".table_width", block, "should be positive, instead was %d", b.Table.TableWidth)
+		}
+		for i, child := range children {
+			rowPath := fmt.Sprintf("%s.children[%d]", contentPath, i)
+			row, ok := child.(*TableRowBlock)
+			if !ok {
+				v.report(rowPath, child, "table children should be table_row, instead was %s", child.GetType())
+				continue
+			}
+			if len(row.TableRow.Cells) != b.Table.TableWidth {
+				v.report(rowPath+".table_row.cells", child, "should have %d cells (table_width), instead has %d",
+					b.Table.TableWidth, len(row.TableRow.Cells))
+			}
+		}
+	case *BookmarkBlock:
+		v.validateURL(contentPath+".url", block, b.Bookmark.URL)
+	case *EmbedBlock:
+		v.validateURL(contentPath+".url", block, b.Embed.URL)
+	case *ImageBlock:
+		v.validateFile(contentPath, block, b.Image)
+	case *VideoBlock:
+		v.validateFile(contentPath, block, b.Video)
+	case *AudioBlock:
+		v.validateFile(contentPath, block, b.Audio)
+	case *FileBlock:
+		v.validateFile(contentPath, block, b.File)
+	case *PdfBlock:
+		v.validateFile(contentPath, block, b.Pdf)
+	}
+
+	if content, err := blockContent(block); err == nil {
+		v.validateRichTexts(contentPath, block, content)
+	}
+
+	v.validateChildren(contentPath+".children", block, children)
+}
+
+// validateFile validates the URL of the external file at the path.
+func (v *blockValidator) validateFile(path string, block Block, file File) {
+	if file.Type == FileTypeExternal {
+		rawURL := ""
+		if file.External != nil {
+			rawURL = file.External.URL
+		}
+		v.validateURL(path+".external.url", block, rawURL)
+	}
+}
+
+// validateURL validates the URL at the path.
+func (v *blockValidator) validateURL(path string, block Block, rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.report(path, block, "should be a valid http(s) URL, instead was %q", rawURL)
+	}
+}
+
+// validateRichTexts validates the rich text arrays found in the JSON value at the path.
+func (v *blockValidator) validateRichTexts(path string, block Block, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			v.validateRichTexts(path+"."+key, block, value[key])
+		}
+	case []any:
+		if !isRichTextArray(value) {
+			for i, item := range value {
+				v.validateRichTexts(fmt.Sprintf("%s[%d]", path, i), block, item)
+			}
+			return
+		}
+
+		if len(value) > maxRichTexts {
+			v.report(path, block, "should have at most %d rich texts, instead has %d", maxRichTexts, len(value))
+		}
+		for i, item := range value {
+			text, _ := item.(map[string]any)["text"].(map[string]any)
+			content, _ := text["content"].(string)
+			if length := utf8.RuneCountInString(content); length > maxRichTextLength {
+				v.report(fmt.Sprintf("%s[%d].text.content", path, i), block,
+					"should have at most %d characters, instead has %d", maxRichTextLength, length)
+			}
+		}
+	}
+}
+
+// isRichTextArray returns true if the JSON array is a non-empty array of rich text objects.
+func isRichTextArray(value []any) bool {
+	if len(value) == 0 {
+		return false
+	}
+	for _, item := range value {
+		object, ok := item.(map[string]any)
+		if !ok || !isRichTextObject(object) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRichTextObject returns true if the JSON object has a text, mention or equation object.
+// The type isn't checked, as it's optional in requests.
+func isRichTextObject(object map[string]any) bool {
+	for _, richTextType := range []RichTextType{RichTextTypeText, RichTextTypeMention, RichTextTypeEquation} {
+		if _, ok := object[richTextType.String()].(map[string]any); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package notion_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/build"
+)
+
+func TestValidateBlocks(t *testing.T) {
+	long := strings.Repeat("ё", 2001)
+	many := make([]any, 101)
+	for i := range many {
+		many[i] = "x"
+	}
+	manyBlocks := make(notion.Blocks, 101)
+	for i := range manyBlocks {
+		manyBlocks[i] = toggle("x")
+	}
+
+	tests := []struct {
+		name   string
+		blocks notion.Blocks
+		want   []string
+	}{
+		{
+			name: "valid blocks",
+			blocks: build.Doc().
+				P("text", build.Bold(strings.Repeat("a", 2000))).
+				Columns(func(b *build.Builder) { b.P("left") }, func(b *build.Builder) { b.P("right") }).
+				Table([][]any{{"a", "b"}, {"c", "d"}}).
+				Bookmark("https://example.com").
+				Image("http://example.com/image.png").
+				Blocks(),
+		},
+		{
+			name: "column lists",
+			blocks: build.Doc().
+				Columns(func(b *build.Builder) { b.P("single") }).
+				Add(notion.NewColumnListBlock(notion.ColumnList{AtomChildren: notion.AtomChildren{
+					Children: notion.Blocks{notion.NewColumnBlock(notion.Column{}), toggle("not a column")},
+				}})).
+				Add(notion.NewColumnBlock(notion.Column{})).
+				Blocks(),
+			want: []string{
+				"[0]: column_list should have at least 2 columns, instead has 1",
+				"[1].column_list.children[1]: column_list children should be column, instead was toggle",
+				"[2]: column should be a child of column_list",
+			},
+		},
+		{
+			name: "tables",
+			blocks: notion.Blocks{notion.NewTableBlock(notion.Table{
+				TableWidth: 2,
+				AtomChildren: notion.AtomChildren{Children: notion.Blocks{
+					notion.NewTableRowBlock(notion.TableRow{Cells: []notion.RichTexts{{}, {}}}),
+					notion.NewTableRowBlock(notion.TableRow{Cells: []notion.RichTexts{{}}}),
+					toggle("row"),
+				}},
+			})},
+			want: []string{
+				"[0].table.children[1].table_row.cells: should have 2 cells (table_width), instead has 1",
+				"[0].table.children[2]: table children should be table_row, instead was toggle",
+			},
+		},
+		{
+			name:   "children count",
+			blocks: notion.Blocks{toggle("parent", manyBlocks...)},
+			want:   []string{"[0].toggle.children: should have at most 100 children, instead has 101"},
+		},
+		{
+			name: "rich texts",
+			blocks: build.Doc().
+				Toggle("ok", func(b *build.Builder) { b.P("a", long) }).
+				P(many...).
+				Table([][]any{{"a", long}}).
+				Blocks(),
+			want: []string{
+				"[0].toggle.children[0].paragraph.rich_text[1].text.content: should have at most 2000 characters, instead has 2001",
+				"[1].paragraph.rich_text: should have at most 100 rich texts, instead has 101",
+				"[2].table.children[0].table_row.cells[1][0].text.content: should have at most 2000 characters, instead has 2001",
+			},
+		},
+		{
+			name: "rich texts without type",
+			blocks: notion.Blocks{notion.NewQuoteBlock(notion.Quote{
+				RichText: notion.RichTexts{{Text: &notion.Text{Content: "ok"}}, {Text: &notion.Text{Content: long}}},
+			})},
+			want: []string{
+				"[0].quote.rich_text[1].text.content: should have at most 2000 characters, instead has 2001",
+			},
+		},
+		{
+			name: "URLs",
+			blocks: build.Doc().
+				Bookmark("example.com").
+				Embed("").
+				File("ftp://example.com/file").
+				Pdf("https://example.com/a.pdf").
+				Blocks(),
+			want: []string{
+				`[0].bookmark.url: should be a valid http(s) URL, instead was "example.com"`,
+				`[1].embed.url: should be a valid http(s) URL, instead was ""`,
+				`[2].file.external.url: should be a valid http(s) URL, instead was "ftp://example.com/file"`,
+			},
+		},
+		{
+			name:   "templates",
+			blocks: build.Doc().Template("template").Blocks(),
+			want:   []string{"[0]: template blocks are deprecated and can't be created"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range notion.ValidateBlocks(tt.blocks) {
+				assert.NotNil(t, issue.Block)
+				got = append(got, issue.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
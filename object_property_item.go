@@ -49,22 +49,18 @@ type PropertyArray []Property
 // UnmarshalJSON implements custom unmarshalling for PropertyArray
 func (arr *PropertyArray) UnmarshalJSON(data []byte) error {
 	var err error
-	mapArr := make([]map[string]interface{}, 0)
-	if err = json.Unmarshal(data, &mapArr); err != nil {
+	rawArr := make([]json.RawMessage, 0)
+	if err = json.Unmarshal(data, &rawArr); err != nil {
 		return err
 	}
 
-	result := make([]Property, len(mapArr))
-	for i, prop := range mapArr {
-		if result[i], err = decodeProperty(prop); err != nil {
+	result := make([]Property, len(rawArr))
+	for i, raw := range rawArr {
+		if result[i], err = decodeProperty(raw); err != nil {
 			return err
 		}
 	}
 
-	if err = json.Unmarshal(data, &result); err != nil {
-		return err
-	}
-
 	*arr = result
 	return nil
 }
@@ -465,7 +461,7 @@ type Properties map[string]Property
 
 // UnmarshalJSON implements custom unmarshalling for Properties
 func (p *Properties) UnmarshalJSON(data []byte) error {
-	var raw map[string]interface{}
+	var raw map[string]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
@@ -478,36 +474,34 @@ func (p *Properties) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func parsePageProperties(raw map[string]interface{}) (map[string]Property, error) {
-	result := make(map[string]Property)
+func parsePageProperties(raw map[string]json.RawMessage) (map[string]Property, error) {
+	result := make(map[string]Property, len(raw))
 	for k, v := range raw {
-		switch rawProperty := v.(type) {
-		case map[string]interface{}:
-			p, err := decodeProperty(rawProperty)
-			if err != nil {
-				return nil, err
-			}
-			b, err := json.Marshal(rawProperty)
-			if err != nil {
-				return nil, err
-			}
-
-			if err = json.Unmarshal(b, &p); err != nil {
-				return nil, err
-			}
-
-			result[k] = p
-		default:
-			return nil, fmt.Errorf("unsupported property format %T", v)
+		p, err := decodeProperty(v)
+		if err != nil {
+			return nil, err
 		}
+
+		result[k] = p
 	}
 
 	return result, nil
 }
 
-func decodeProperty(raw map[string]interface{}) (Property, error) {
+// decodeProperty decodes the JSON of a property into the struct of its type, peeking at the type first.
+func decodeProperty(data []byte) (Property, error) {
+	var head struct {
+		Type *PropertyType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	if head.Type == nil {
+		return nil, fmt.Errorf("unsupported property format: missing type")
+	}
+
 	var p Property
-	switch PropertyType(raw["type"].(string)) {
+	switch *head.Type {
 	case PropertyTypeTitle:
 		p = &TitleProperty{}
 	case PropertyTypeRichText:
@@ -557,7 +551,11 @@ func decodeProperty(raw map[string]interface{}) (Property, error) {
 	case PropertyTypeButton:
 		p = &ButtonProperty{}
 	default:
-		return nil, fmt.Errorf("unsupported property type: %s", raw["type"].(string))
+		return nil, fmt.Errorf("unsupported property type: %s", *head.Type)
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
 	}
 
 	return p, nil
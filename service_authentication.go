@@ -3,7 +3,6 @@ package notion
 import (
 	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 )
 
@@ -22,24 +21,16 @@ func newAuthenticationService(api *clientAPI) *AuthenticationService {
 //
 // See https://developers.notion.com/reference/create-a-token
 func (s *AuthenticationService) CreateToken(ctx context.Context, request *TokenCreateRequest) (*TokenCreateResponse, error) {
-	res, err := s.api.requestRaw(ctx, http.MethodPost, "oauth/token", nil, request, true, decodeTokenCreateError)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response TokenCreateResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return call[*TokenCreateResponse](ctx, s.api, &Operation{
+		Service:    ServiceAuthentication,
+		Method:     "CreateToken",
+		HTTPMethod: http.MethodPost,
+		Path:       "oauth/token",
+		Payload:    request,
+		decode:     decodeJSON[TokenCreateResponse],
+		basicAuth:  true,
+		errDecoder: decodeTokenCreateError,
+	})
 }
 
 func decodeTokenCreateError(data []byte) error {
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"iter"
 	"net/http"
+	"reflect"
+	"strings"
 )
 
 const (
@@ -26,23 +28,14 @@ func newBlocksService(api *clientAPI) *BlocksService {
 //
 // Get https://developers.notion.com/reference/retrieve-a-block
 func (s *BlocksService) Get(ctx context.Context, id BlockID) (Block, error) {
-	res, err := s.api.request(ctx, http.MethodGet, fmt.Sprintf(pathBlocks+"/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response map[string]interface{}
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-	return decodeBlock(response)
+	return call[Block](ctx, s.api, &Operation{
+		Service:    ServiceBlocks,
+		Method:     "Get",
+		ObjectID:   id,
+		HTTPMethod: http.MethodGet,
+		Path:       fmt.Sprintf(pathBlocks+"/%s", id.String()),
+		decode:     decodeBlockJSON,
+	})
 }
 
 // GetChildren returns a paginated array of child block objects contained in the block using
@@ -51,24 +44,28 @@ func (s *BlocksService) Get(ctx context.Context, id BlockID) (Block, error) {
 //
 // See https://developers.notion.com/reference/get-block-children
 func (s *BlocksService) GetChildren(ctx context.Context, id BlockID, pagination *Pagination) (*GetChildrenResponse, error) {
-	res, err := s.api.request(ctx, http.MethodGet, fmt.Sprintf(pathBlocks+"/%s/children", id.String()), pagination.ToQuery(), nil)
-	if err != nil {
-		return nil, err
-	}
+	return call[*GetChildrenResponse](ctx, s.api, &Operation{
+		Service:    ServiceBlocks,
+		Method:     "GetChildren",
+		ObjectID:   id,
+		HTTPMethod: http.MethodGet,
+		Path:       fmt.Sprintf(pathBlocks+"/%s/children", id.String()),
+		Query:      pagination.ToQuery(),
+		decode:     decodeJSON[GetChildrenResponse],
+	})
+}
 
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
+// AllChildren returns an iterator over all the child blocks of the block (first level only).
+// It requests the next pages of children while iterating, starting with pagination.StartCursor.
+// pagination.PageSize (if set) is used as the size of each requested page.
+func (s *BlocksService) AllChildren(ctx context.Context, id BlockID, pagination *Pagination) iter.Seq2[Block, error] {
+	return paginate(pagination.startCursor(), func(cursor Cursor) ([]Block, AtomPaginatedResponse, error) {
+		res, err := s.GetChildren(ctx, id, pagination.withCursor(cursor))
+		if err != nil {
+			return nil, AtomPaginatedResponse{}, err
 		}
-	}()
-
-	response := &GetChildrenResponse{}
-	err = json.NewDecoder(res.Body).Decode(response)
-	if err != nil {
-		return nil, err
-	}
-
-	return response, nil
+		return res.Results, res.AtomPaginatedResponse, nil
+	})
 }
 
 // AppendChildren creates and appends new children blocks to the parent block_id specified.
@@ -85,23 +82,15 @@ func (s *BlocksService) GetChildren(ctx context.Context, id BlockID, pagination
 //
 // See https://developers.notion.com/reference/patch-block-children
 func (s *BlocksService) AppendChildren(ctx context.Context, id BlockID, requestBody *AppendBlockChildrenRequest) (*AppendBlockChildrenResponse, error) {
-	res, err := s.api.request(ctx, http.MethodPatch, fmt.Sprintf(pathBlocks+"/%s/children", id.String()), nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response AppendBlockChildrenResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-	return &response, nil
+	return call[*AppendBlockChildrenResponse](ctx, s.api, &Operation{
+		Service:    ServiceBlocks,
+		Method:     "AppendChildren",
+		ObjectID:   id,
+		HTTPMethod: http.MethodPatch,
+		Path:       fmt.Sprintf(pathBlocks+"/%s/children", id.String()),
+		Payload:    requestBody,
+		decode:     decodeJSON[AppendBlockChildrenResponse],
+	})
 }
 
 // Update updates the content for the specified block_id based on the block type.
@@ -113,23 +102,31 @@ func (s *BlocksService) AppendChildren(ctx context.Context, id BlockID, requestB
 //
 // See https://developers.notion.com/reference/update-a-block
 func (s *BlocksService) Update(ctx context.Context, id BlockID, requestBody *BlockUpdateRequest) (Block, error) {
-	res, err := s.api.request(ctx, http.MethodPatch, fmt.Sprintf(pathBlocks+"/%s", id.String()), nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
+	return call[Block](ctx, s.api, &Operation{
+		Service:    ServiceBlocks,
+		Method:     "Update",
+		ObjectID:   id,
+		HTTPMethod: http.MethodPatch,
+		Path:       fmt.Sprintf(pathBlocks+"/%s", id.String()),
+		Payload:    requestBody,
+		decode:     decodeBlockJSON,
+	})
+}
 
-	var response map[string]interface{}
-	err = json.NewDecoder(res.Body).Decode(&response)
+// UpdateFrom updates the block with the ID of the given block to its type-specific content
+// (see NewBlockUpdateRequest).
+func (s *BlocksService) UpdateFrom(ctx context.Context, block Block) (Block, error) {
+	req, err := NewBlockUpdateRequest(block)
 	if err != nil {
 		return nil, err
 	}
-	return decodeBlock(response)
+	return s.Update(ctx, block.GetID(), req)
+}
+
+// Restore restores the archived block (moves it out of the trash).
+func (s *BlocksService) Restore(ctx context.Context, id BlockID) (Block, error) {
+	archived := false
+	return s.Update(ctx, id, &BlockUpdateRequest{Archived: &archived})
 }
 
 // Delete sets a Block object, including page blocks, to archived: true using the ID
@@ -140,23 +137,14 @@ func (s *BlocksService) Update(ctx context.Context, id BlockID, requestBody *Blo
 //
 // See https://developers.notion.com/reference/delete-a-block
 func (s *BlocksService) Delete(ctx context.Context, id BlockID) (Block, error) {
-	res, err := s.api.request(ctx, http.MethodDelete, fmt.Sprintf(pathBlocks+"/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response map[string]interface{}
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-	return decodeBlock(response)
+	return call[Block](ctx, s.api, &Operation{
+		Service:    ServiceBlocks,
+		Method:     "Delete",
+		ObjectID:   id,
+		HTTPMethod: http.MethodDelete,
+		Path:       fmt.Sprintf(pathBlocks+"/%s", id.String()),
+		decode:     decodeBlockJSON,
+	})
 }
 
 // AppendBlockChildrenRequest is a type for append block children request.
@@ -174,29 +162,122 @@ type GetChildrenResponse struct {
 }
 
 // BlockUpdateRequest is a type for block update request.
+// Set the field of the block type only (see NewBlockUpdateRequest) and/or the archived state.
 type BlockUpdateRequest struct {
-	Paragraph        *Paragraph `json:"paragraph,omitempty"`
-	Heading1         *Heading   `json:"heading_1,omitempty"`
-	Heading2         *Heading   `json:"heading_2,omitempty"`
-	Heading3         *Heading   `json:"heading_3,omitempty"`
-	BulletedListItem *ListItem  `json:"bulleted_list_item,omitempty"`
-	NumberedListItem *ListItem  `json:"numbered_list_item,omitempty"`
-	Code             *Code      `json:"code,omitempty"`
-	ToDo             *ToDo      `json:"to_do,omitempty"`
-	Toggle           *Toggle    `json:"toggle,omitempty"`
-	Embed            *Embed     `json:"embed,omitempty"`
-	Image            *File      `json:"image,omitempty"`
-	Video            *File      `json:"video,omitempty"`
-	File             *File      `json:"file,omitempty"`
-	Pdf              *File      `json:"pdf,omitempty"`
-	Bookmark         *Bookmark  `json:"bookmark,omitempty"`
-	Template         *Template  `json:"template,omitempty"`
-	Callout          *Callout   `json:"callout,omitempty"`
-	Equation         *Equation  `json:"equation,omitempty"`
-	Quote            *Quote     `json:"quote,omitempty"`
-	TableRow         *TableRow  `json:"table_row,omitempty"`
+	Paragraph        *Paragraph       `json:"paragraph,omitempty"`
+	Heading1         *Heading         `json:"heading_1,omitempty"`
+	Heading2         *Heading         `json:"heading_2,omitempty"`
+	Heading3         *Heading         `json:"heading_3,omitempty"`
+	BulletedListItem *ListItem        `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *ListItem        `json:"numbered_list_item,omitempty"`
+	Code             *Code            `json:"code,omitempty"`
+	ToDo             *ToDo            `json:"to_do,omitempty"`
+	Toggle           *Toggle          `json:"toggle,omitempty"`
+	Embed            *Embed           `json:"embed,omitempty"`
+	Image            *File            `json:"image,omitempty"`
+	Video            *File            `json:"video,omitempty"`
+	Audio            *File            `json:"audio,omitempty"`
+	File             *File            `json:"file,omitempty"`
+	Pdf              *File            `json:"pdf,omitempty"`
+	Bookmark         *Bookmark        `json:"bookmark,omitempty"`
+	Template         *Template        `json:"template,omitempty"`
+	Callout          *Callout         `json:"callout,omitempty"`
+	Equation         *Equation        `json:"equation,omitempty"`
+	Quote            *Quote           `json:"quote,omitempty"`
+	Table            *Table           `json:"table,omitempty"`
+	TableRow         *TableRow        `json:"table_row,omitempty"`
+	TableOfContents  *TableOfContents `json:"table_of_contents,omitempty"`
+	Divider          *Divider         `json:"divider,omitempty"`
+	Breadcrumb       *Breadcrumb      `json:"breadcrumb,omitempty"`
+	Column           *Column          `json:"column,omitempty"`
+	ColumnList       *ColumnList      `json:"column_list,omitempty"`
+	SyncedBlock      *Synced          `json:"synced_block,omitempty"`
+	LinkToPage       *LinkToPage      `json:"link_to_page,omitempty"`
+	LinkPreview      *LinkPreview     `json:"link_preview,omitempty"`
+	ChildPage        *ChildPage       `json:"child_page,omitempty"`
+	ChildDatabase    *ChildDatabase   `json:"child_database,omitempty"`
+
+	// Archived archives (true) or restores (false) the block.
+	Archived *bool `json:"archived,omitempty"`
+	// InTrash moves the block to (true) or restores it from (false) the trash.
+	InTrash *bool `json:"in_trash,omitempty"`
+
+	// Extra holds the payloads of the block types having no field above, keyed by the block type.
+	Extra map[string]any `json:"-"`
+}
+
+// MarshalJSON merges the Extra payloads into the request and drops the children:
+// they can't be updated, only appended.
+func (r BlockUpdateRequest) MarshalJSON() ([]byte, error) {
+	type request BlockUpdateRequest
+	data, err := json.Marshal(request(r))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for key, value := range r.Extra {
+		raw[key] = value
+	}
+	for _, value := range raw {
+		if content, ok := value.(map[string]any); ok {
+			delete(content, "children")
+		}
+	}
+
+	return json.Marshal(raw)
 }
 
+// NewBlockUpdateRequest returns the request updating a block to the type-specific content of the given one.
+// It works for any registered block type: the types having no field in BlockUpdateRequest go to Extra.
+// The archived state is not copied: set Archived or InTrash explicitly to change it.
+func NewBlockUpdateRequest(block Block) (*BlockUpdateRequest, error) {
+	if block.GetType() == BlockTypeUnsupported {
+		return nil, fmt.Errorf("%s blocks can't be updated", block.GetType())
+	}
+	content, err := blockContent(block)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("%s block has no %s content", block.GetType(), block.GetType())
+	}
+
+	req := &BlockUpdateRequest{}
+	payload := map[string]any{string(block.GetType()): content}
+	if _, ok := blockUpdateFields[block.GetType()]; !ok {
+		req.Extra = payload
+		return req, nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// blockUpdateFields are the block types having their fields in BlockUpdateRequest.
+var blockUpdateFields = func() map[BlockType]bool {
+	fields := make(map[BlockType]bool)
+	t := reflect.TypeOf(BlockUpdateRequest{})
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Pointer || field.Type.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		fields[BlockType(name)] = true
+	}
+	return fields
+}()
+
 // AppendBlockChildrenResponse is a type for append block children response.
 type AppendBlockChildrenResponse struct {
 	Object  ObjectType `json:"object"`
@@ -206,8 +287,8 @@ type AppendBlockChildrenResponse struct {
 // UnmarshalJSON does custom unmarshalling for AppendBlockChildrenResponse
 func (r *AppendBlockChildrenResponse) UnmarshalJSON(data []byte) error {
 	type appendBlockResponse struct {
-		Object  ObjectType       `json:"object"`
-		Results []map[string]any `json:"results"`
+		Object  ObjectType        `json:"object"`
+		Results []json.RawMessage `json:"results"`
 	}
 
 	var raw appendBlockResponse
@@ -229,6 +310,3 @@ func (r *AppendBlockChildrenResponse) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
-
-// TODO: write a test that uses reflect and ensure that such structs
-//       above always have the all types being included
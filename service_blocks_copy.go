@@ -0,0 +1,262 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUncopyableBlock is returned by FailOnUncopyable.
+var ErrUncopyableBlock = errors.New("block can't be copied")
+
+// UncopyablePolicy decides what to do with a block that can't be re-created by the API (see IsCopyable).
+// It returns a replacement block, nil to skip the block, or an error to abort the copy.
+type UncopyablePolicy func(block Block) (Block, error)
+
+// FailOnUncopyable is an UncopyablePolicy that aborts the copy (before anything is created).
+func FailOnUncopyable(block Block) (Block, error) {
+	return nil, fmt.Errorf("%w: %s %s", ErrUncopyableBlock, block.GetType(), block.GetID())
+}
+
+// SkipUncopyable is an UncopyablePolicy that leaves uncopyable blocks out of the copy.
+func SkipUncopyable(Block) (Block, error) {
+	return nil, nil
+}
+
+// LinkUncopyable is an UncopyablePolicy that replaces child pages and databases with links to them
+// and original synced blocks with references to them (so the copy stays in sync with the source).
+// Other uncopyable blocks are skipped.
+func LinkUncopyable(block Block) (Block, error) {
+	switch block.GetType() {
+	case BlockTypeChildPage:
+		return NewLinkToPageBlock(block.GetID()), nil
+	case BlockTypeChildDatabase:
+		return NewLinkToDatabaseBlock(block.GetID()), nil
+	case BlockTypeSyncedBlock:
+		return NewSyncedBlock(Synced{SyncedFrom: &SyncedFrom{BlockID: block.GetID()}}), nil
+	default:
+		return nil, nil
+	}
+}
+
+// IsCopyable reports whether the block can be re-created by the API:
+// child pages and databases, link previews, templates and unsupported blocks can't be created,
+// files uploaded to Notion can't be re-attached by their (expiring) URLs,
+// and a copy of an original synced block wouldn't be synced with the references to the source.
+func IsCopyable(block Block) bool {
+	switch block.GetType() {
+	case BlockTypeChildPage, BlockTypeChildDatabase, BlockTypeLinkPreview, BlockTypeTemplate, BlockTypeUnsupported:
+		return false
+	}
+
+	switch b := block.(type) {
+	case *UnsupportedBlock:
+		return false
+	case *SyncedBlock:
+		return b.Synced.SyncedFrom != nil
+	case *ImageBlock:
+		return b.Image.Type != FileTypeFile
+	case *VideoBlock:
+		return b.Video.Type != FileTypeFile
+	case *AudioBlock:
+		return b.Audio.Type != FileTypeFile
+	case *FileBlock:
+		return b.File.Type != FileTypeFile
+	case *PdfBlock:
+		return b.Pdf.Type != FileTypeFile
+	default:
+		return true
+	}
+}
+
+// CopyTreeOptions configures BlocksService.CopyTree.
+type CopyTreeOptions struct {
+	// Move archives the source block after a successful copy.
+	// A tree with uncopyable blocks can't be moved: they would be archived with the source.
+	Move bool
+	// After is the ID of the existing child block of the destination the copy is inserted after.
+	// If empty, the copy is appended to the end of the destination.
+	After BlockID
+	// Uncopyable is the policy for the blocks that can't be re-created (see IsCopyable).
+	// FailOnUncopyable is used by default.
+	Uncopyable UncopyablePolicy
+	CopyContentOptions
+}
+
+// CopyContentOptions configures copying the block content by BlocksService.CopyTree and PagesService.Duplicate.
+type CopyContentOptions struct {
+	// ExternalizeFiles copies the files uploaded to Notion as external files instead of applying
+	// the Uncopyable policy to them. See CloneOptions.ExternalizeFiles.
+	ExternalizeFiles bool
+	// Concurrency is the maximum number of parallel requests reading the source content.
+	// Zero means DefaultTreeConcurrency.
+	Concurrency int
+}
+
+// CopyTreeResult is the result of BlocksService.CopyTree.
+type CopyTreeResult struct {
+	// ID is the ID of the copy of the source block. It's empty if the policy skipped the source block.
+	ID BlockID
+	// IDs maps the IDs of the copied source blocks (at all levels) to the IDs of their copies.
+	IDs map[BlockID]BlockID
+	// Uncopyable are the source blocks handled by the Uncopyable policy (replaced or skipped).
+	Uncopyable Blocks
+}
+
+// CopyTree copies the block with its children of any depth to the destination parent block (or page).
+// The API can't move blocks, so the copy is a new tree of blocks: the read-only fields (IDs, timestamps, authors)
+// are not copied. In move mode the source block is archived after a successful copy.
+//
+// The source tree is read before anything is created, so an error of the Uncopyable policy
+// (or uncopyable blocks in move mode) aborts the copy with no changes. If creating the copy fails, the result holds the IDs of the blocks created so far.
+func (s *BlocksService) CopyTree(ctx context.Context, srcID, dstParentID BlockID, opts *CopyTreeOptions) (*CopyTreeResult, error) {
+	if opts == nil {
+		opts = &CopyTreeOptions{}
+	}
+	policy := opts.Uncopyable
+	if policy == nil {
+		policy = FailOnUncopyable
+	}
+	c := newTreeCopier(policy, opts.CopyContentOptions)
+
+	src, err := s.Get(ctx, srcID)
+	if err != nil {
+		return nil, err
+	}
+	if src.GetHasChildren() && c.isCopyable(src) && !isSyncedReference(src) && canHoldChildren(src.GetType()) {
+		children, err := c.getTree(ctx, s, srcID)
+		if err != nil {
+			return nil, err
+		}
+		src.(HierarchicalBlock).SetChildren(children)
+	}
+
+	result := &CopyTreeResult{IDs: make(map[BlockID]BlockID)}
+	copies, err := c.copyTree(Blocks{src})
+	result.Uncopyable = c.uncopyable
+	if err != nil || len(copies) == 0 {
+		return result, err
+	}
+	// archiving the source would trash the uncopyable blocks (e.g. child pages) left in it
+	if opts.Move && len(c.uncopyable) > 0 {
+		return result, fmt.Errorf("%w: can't move %s with %d uncopyable blocks",
+			ErrUncopyableBlock, srcID, len(c.uncopyable))
+	}
+	copied := copies[0]
+
+	ids, err := s.AppendTree(ctx, dstParentID, copies, &AppendTreeOptions{After: opts.After})
+	for block, id := range ids {
+		if sourceID, ok := c.sources[block]; ok {
+			result.IDs[sourceID] = id
+		}
+	}
+	result.ID = ids[copied]
+	if err != nil {
+		return result, err
+	}
+
+	if opts.Move {
+		if _, err := s.Delete(ctx, srcID); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// treeCopier holds the state of a single CopyTree (or Duplicate) call.
+type treeCopier struct {
+	policy    UncopyablePolicy
+	opts      CopyContentOptions
+	cloneOpts *CloneOptions
+	// sources maps copies to the IDs of their source blocks.
+	sources    map[Block]BlockID
+	uncopyable Blocks
+}
+
+// newTreeCopier creates a treeCopier applying the policy to the uncopyable blocks.
+func newTreeCopier(policy UncopyablePolicy, opts CopyContentOptions) *treeCopier {
+	return &treeCopier{
+		policy:    policy,
+		opts:      opts,
+		cloneOpts: &CloneOptions{ExternalizeFiles: opts.ExternalizeFiles},
+		sources:   make(map[Block]BlockID),
+	}
+}
+
+// getTree reads the children of the block, leaving out the children of the blocks which won't be copied as is.
+func (c *treeCopier) getTree(ctx context.Context, blocks *BlocksService, id BlockID) (Blocks, error) {
+	return blocks.GetTree(ctx, id, &GetTreeOptions{
+		Concurrency: c.opts.Concurrency,
+		Skip:        func(block Block) bool { return !c.isCopyable(block) || isSyncedReference(block) },
+	})
+}
+
+// copyTree returns the copies of the blocks (with the copies of their children) ready to be created.
+// The blocks skipped by the policy are left out.
+func (c *treeCopier) copyTree(blocks Blocks) (Blocks, error) {
+	pruned, err := c.prune(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	copies, err := pruned.CloneForCreate(c.cloneOpts)
+	if err != nil {
+		return nil, err
+	}
+	c.mapSources(pruned, copies)
+
+	return copies, nil
+}
+
+// prune applies the policy to the uncopyable blocks at all levels, replacing or dropping them.
+// The children of the (fetched, so owned by the copier) source blocks are replaced with the pruned ones.
+func (c *treeCopier) prune(blocks Blocks) (Blocks, error) {
+	pruned := make(Blocks, 0, len(blocks))
+	for _, block := range blocks {
+		if !c.isCopyable(block) {
+			c.uncopyable = append(c.uncopyable, block)
+			replacement, err := c.policy(block)
+			if err != nil {
+				return nil, err
+			}
+			if replacement != nil {
+				pruned = append(pruned, replacement)
+			}
+			continue
+		}
+
+		// children of synced references belong to the original block
+		if children := childrenOf(block); len(children) > 0 && !isSyncedReference(block) {
+			prunedChildren, err := c.prune(children)
+			if err != nil {
+				return nil, err
+			}
+			block.(HierarchicalBlock).SetChildren(prunedChildren)
+		}
+		pruned = append(pruned, block)
+	}
+
+	return pruned, nil
+}
+
+// isCopyable returns true if the block can be re-created, including the hosted files being externalized.
+func (c *treeCopier) isCopyable(block Block) bool {
+	return IsCopyable(block) || c.opts.ExternalizeFiles && hostedFile(block) != nil
+}
+
+// mapSources maps the copies to the IDs of their sources (the replacements made by the policy have no IDs).
+func (c *treeCopier) mapSources(sources, copies Blocks) {
+	for i, copied := range copies {
+		if id := sources[i].GetID(); id != "" {
+			c.sources[copied] = id
+		}
+		c.mapSources(childrenOf(sources[i]), childrenOf(copied))
+	}
+}
+
+// isSyncedReference returns true for synced blocks referencing an original synced block.
+func isSyncedReference(block Block) bool {
+	synced, ok := block.(*SyncedBlock)
+	return ok && synced.Synced.SyncedFrom != nil
+}
@@ -0,0 +1,149 @@
+package notion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func TestBlocksService_CopyTree(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	t.Run("should copy a subtree", func(t *testing.T) {
+		src, dst := newTestPage(t, srv, "Page"), newTestPage(t, srv, "Page")
+		blocks := srv.AddBlocks(src.ID, toggle("a", toggle("b", toggle("c", toggle("d"))), toggle("e")))
+
+		res, err := client.Blocks.CopyTree(ctx, blocks[0].GetID(), dst.ID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, res.Uncopyable)
+		assert.Len(t, res.IDs, 5)
+		assert.Equal(t, res.IDs[blocks[0].GetID()], res.ID)
+
+		copied, err := client.Blocks.GetTree(ctx, dst.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "a\n  b\n    c\n      d\n  e\n", outline(copied, ""))
+		assert.NotEqual(t, blocks[0].GetID(), copied[0].GetID())
+		assert.Equal(t, dst.ID, copied[0].GetParent().PageID)
+
+		// the source is intact
+		source, err := client.Blocks.GetTree(ctx, src.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "a\n  b\n    c\n      d\n  e\n", outline(source, ""))
+	})
+
+	t.Run("should move a subtree", func(t *testing.T) {
+		src, dst := newTestPage(t, srv, "Page"), newTestPage(t, srv, "Page")
+		blocks := srv.AddBlocks(src.ID, toggle("first"), toggle("moved", toggle("child")), toggle("last"))
+		existing := srv.AddBlocks(dst.ID, toggle("x"), toggle("y"))
+
+		res, err := client.Blocks.CopyTree(ctx, blocks[1].GetID(), dst.ID, &notion.CopyTreeOptions{
+			Move:  true,
+			After: existing[0].GetID(),
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.ID)
+
+		assert.True(t, srv.Block(blocks[1].GetID()).GetArchived())
+		assert.Equal(t, "first\nlast\n", outline(srv.Children(src.ID), ""))
+
+		moved, err := client.Blocks.GetTree(ctx, dst.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "x\nmoved\n  child\ny\n", outline(moved, ""))
+	})
+
+	t.Run("should apply the uncopyable policy", func(t *testing.T) {
+		image := notion.NewImageBlock(notion.File{
+			Type: notion.FileTypeFile,
+			File: &notion.FileData{URL: "https://files.notion.so/image.png"},
+		})
+		external := notion.NewImageBlock(notion.File{
+			Type:     notion.FileTypeExternal,
+			External: &notion.FileData{URL: "https://example.com/image.png"},
+		})
+		synced := notion.NewSyncedBlock(notion.Synced{})
+		synced.SetChildren(notion.Blocks{toggle("synced content")})
+
+		src := newTestPage(t, srv, "Page")
+		blocks := srv.AddBlocks(src.ID, toggle("root", notion.NewChildPageBlock("Sub"), image, external, synced))
+		rootID := blocks[0].GetID()
+
+		t.Run("fail by default", func(t *testing.T) {
+			dst := newTestPage(t, srv, "Page")
+
+			_, err := client.Blocks.CopyTree(ctx, rootID, dst.ID, nil)
+			require.ErrorIs(t, err, notion.ErrUncopyableBlock)
+			assert.Empty(t, srv.Children(dst.ID))
+		})
+
+		t.Run("skip", func(t *testing.T) {
+			dst := newTestPage(t, srv, "Page")
+
+			res, err := client.Blocks.CopyTree(ctx, rootID, dst.ID, &notion.CopyTreeOptions{Uncopyable: notion.SkipUncopyable})
+			require.NoError(t, err)
+			assert.Len(t, res.Uncopyable, 3)
+
+			copied, err := client.Blocks.GetTree(ctx, dst.ID, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "root\n  image\n", outline(copied, ""))
+		})
+
+		t.Run("link", func(t *testing.T) {
+			dst := newTestPage(t, srv, "Page")
+
+			res, err := client.Blocks.CopyTree(ctx, rootID, dst.ID, &notion.CopyTreeOptions{Uncopyable: notion.LinkUncopyable})
+			require.NoError(t, err)
+			assert.Len(t, res.Uncopyable, 3)
+
+			copied, err := client.Blocks.GetTree(ctx, dst.ID, nil)
+			require.NoError(t, err)
+			require.Equal(t, "root\n  link_to_page\n  image\n  synced_block\n", outline(copied, ""))
+
+			children := copied[0].(notion.HierarchicalBlock).GetChildren()
+			link := children[0].(*notion.LinkToPageBlock)
+			sources := srv.Children(rootID)
+			assert.Equal(t, sources[0].GetID(), link.LinkToPage.PageID)
+			reference := children[2].(*notion.SyncedBlock)
+			require.NotNil(t, reference.Synced.SyncedFrom)
+			assert.Equal(t, sources[3].GetID(), reference.Synced.SyncedFrom.BlockID)
+		})
+
+		t.Run("refuse to move", func(t *testing.T) {
+			dst := newTestPage(t, srv, "Page")
+
+			res, err := client.Blocks.CopyTree(ctx, rootID, dst.ID, &notion.CopyTreeOptions{
+				Move:       true,
+				Uncopyable: notion.LinkUncopyable,
+			})
+			require.ErrorIs(t, err, notion.ErrUncopyableBlock)
+			assert.Len(t, res.Uncopyable, 3)
+			assert.Empty(t, srv.Children(dst.ID))
+			assert.False(t, srv.Block(rootID).GetArchived())
+		})
+
+		t.Run("externalize files", func(t *testing.T) {
+			dst := newTestPage(t, srv, "Page")
+
+			res, err := client.Blocks.CopyTree(ctx, rootID, dst.ID, &notion.CopyTreeOptions{
+				Uncopyable:         notion.SkipUncopyable,
+				CopyContentOptions: notion.CopyContentOptions{ExternalizeFiles: true},
+			})
+			require.NoError(t, err)
+			assert.Len(t, res.Uncopyable, 2)
+			assert.Len(t, res.IDs, 3)
+
+			copied, err := client.Blocks.GetTree(ctx, dst.ID, nil)
+			require.NoError(t, err)
+			require.Equal(t, "root\n  image\n  image\n", outline(copied, ""))
+
+			hosted := copied[0].(notion.HierarchicalBlock).GetChildren()[0].(*notion.ImageBlock)
+			assert.Equal(t, notion.FileTypeExternal, hosted.Image.Type)
+			assert.Equal(t, "https://files.notion.so/image.png", hosted.Image.External.URL)
+		})
+	})
+}
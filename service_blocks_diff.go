@@ -0,0 +1,415 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// DefaultDiffSimilarity is the default minimal similarity of the texts of two blocks to match them.
+const DefaultDiffSimilarity = 0.5
+
+// BlockOpType is a type of BlockOp.
+type BlockOpType string
+
+// nolint:revive
+const (
+	BlockOpUpdate  BlockOpType = "update"
+	BlockOpInsert  BlockOpType = "insert"
+	BlockOpArchive BlockOpType = "archive"
+)
+
+// BlockOp is a single operation of BlockPatch.
+type BlockOp struct {
+	Type BlockOpType
+	// ID is the ID of the existing block to update or archive.
+	ID BlockID
+	// Block is the desired block (update) or the existing block (archive).
+	Block Block
+	// Update is the new content of the block (update).
+	Update *BlockUpdateRequest
+	// ParentID is the ID of the block (or page) the blocks are inserted to (insert).
+	ParentID BlockID
+	// After is the ID of the existing block the blocks are inserted after (insert).
+	// Empty means the end of the parent.
+	After BlockID
+	// Blocks are the blocks (with children) to insert (insert).
+	Blocks Blocks
+}
+
+// String returns a human-readable description of the operation.
+func (op BlockOp) String() string {
+	switch op.Type {
+	case BlockOpUpdate, BlockOpArchive:
+		return fmt.Sprintf("%s %s %s", op.Type, op.Block.GetType(), op.ID)
+	case BlockOpInsert:
+		types := make([]string, len(op.Blocks))
+		for i, block := range op.Blocks {
+			types[i] = block.GetType().String()
+		}
+		position := "at the end"
+		if op.After != "" {
+			position = "after " + op.After.String()
+		}
+		return fmt.Sprintf("insert %s into %s %s", strings.Join(types, ", "), op.ParentID, position)
+	default:
+		return string(op.Type)
+	}
+}
+
+// BlockPatch is a list of operations turning one tree of blocks into another (see DiffBlocks).
+// Archives go last, so the blocks they archive can be used as insertion points.
+type BlockPatch []BlockOp
+
+// String returns the plan of the patch: an operation per line.
+func (p BlockPatch) String() string {
+	var sb strings.Builder
+	for _, op := range p {
+		sb.WriteString(op.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// DiffOptions configures DiffBlocks.
+type DiffOptions struct {
+	// Similarity is the minimal similarity (from 0 to 1) of the texts of two blocks of the same type to match them.
+	// Zero means DefaultDiffSimilarity.
+	Similarity float64
+	// Keep reports the existing blocks that must not be archived even if they are missing in the desired tree
+	// (e.g. SkipChildPages, so the sub-pages survive the sync).
+	Keep func(block Block) bool
+}
+
+// DiffBlocks returns a minimal patch turning the existing children of the parent into the desired ones.
+// The existing blocks are expected to be fetched with their children (see BlocksService.GetTree).
+//
+// The desired blocks are matched to the existing ones by IDs, then by the similarity of their texts
+// (the order of the matched blocks is preserved). Matched blocks with different content are updated in place,
+// the rest of the existing blocks are archived, the rest of the desired blocks are inserted.
+// The blocks left unmatched between two matched ones are updated in place if their types are the same.
+//
+// The API can't insert blocks at the beginning of a parent, so if the desired tree starts with new blocks
+// and the first existing block is kept, the latter is re-created.
+func DiffBlocks(parentID BlockID, existing, desired Blocks, opts *DiffOptions) BlockPatch {
+	if opts == nil {
+		opts = &DiffOptions{}
+	}
+	d := &differ{similarity: opts.Similarity, keep: opts.Keep}
+	if d.similarity == 0 {
+		d.similarity = DefaultDiffSimilarity
+	}
+
+	d.diff(parentID, existing, desired)
+	return append(d.ops, d.archives...)
+}
+
+// differ holds the state of a single DiffBlocks call.
+type differ struct {
+	similarity float64
+	keep       func(block Block) bool
+
+	ops      BlockPatch
+	archives BlockPatch
+}
+
+// diff adds the operations turning the existing children of the parent into the desired ones.
+func (d *differ) diff(parentID BlockID, existing, desired Blocks) {
+	matches := d.match(existing, desired)
+
+	var insert *BlockOp
+	flush := func() {
+		if insert != nil {
+			d.ops = append(d.ops, *insert)
+			insert = nil
+		}
+	}
+	// anchor is the existing block the following new blocks go after
+	anchor := BlockID("")
+	if len(existing) > 0 && matches.old[0] < 0 {
+		anchor = existing[0].GetID()
+	}
+
+	for j, block := range desired {
+		i := matches.new[j]
+		if i < 0 {
+			if insert == nil {
+				insert = &BlockOp{Type: BlockOpInsert, ParentID: parentID, After: anchor}
+			}
+			insert.Blocks = append(insert.Blocks, block)
+			continue
+		}
+		flush()
+
+		old := existing[i]
+		anchor = old.GetID()
+		if !equalBlockContent(old, block) {
+			update, err := NewBlockUpdateRequest(block)
+			if err != nil || !isUpdatable(block) {
+				// can't be updated: re-create it in place
+				insert = &BlockOp{Type: BlockOpInsert, ParentID: parentID, After: anchor, Blocks: Blocks{block}}
+				d.archive(old)
+				continue
+			}
+			d.ops = append(d.ops, BlockOp{Type: BlockOpUpdate, ID: old.GetID(), Block: block, Update: update})
+		}
+		if !isSyncedReference(old) && canHoldChildren(old.GetType()) {
+			d.diff(old.GetID(), childrenOf(old), childrenOf(block))
+		}
+	}
+	flush()
+
+	for i, old := range existing {
+		if matches.old[i] < 0 && (d.keep == nil || !d.keep(old)) {
+			d.archive(old)
+		}
+	}
+}
+
+// archive adds archiving of the existing block.
+func (d *differ) archive(block Block) {
+	d.archives = append(d.archives, BlockOp{Type: BlockOpArchive, ID: block.GetID(), Block: block})
+}
+
+// blockMatches maps the indices of matched existing and desired blocks to each other (-1 for unmatched).
+type blockMatches struct {
+	old []int
+	new []int
+}
+
+// match matches the desired blocks to the existing ones, preserving their order.
+func (d *differ) match(existing, desired Blocks) blockMatches {
+	n, m := len(existing), len(desired)
+	oldTexts, newTexts := make([]string, n), make([]string, m)
+	// the contents of the blocks that can't be marshalled are invalid: they never match
+	oldContents, newContents := make([]any, n), make([]any, m)
+	oldValid, newValid := make([]bool, n), make([]bool, m)
+	for i, block := range existing {
+		oldTexts[i] = blockText(block)
+		content, err := canonicalContent(block)
+		oldContents[i], oldValid[i] = content, err == nil
+	}
+	for j, block := range desired {
+		newTexts[j] = blockText(block)
+		content, err := canonicalContent(block)
+		newContents[j], newValid[j] = content, err == nil
+	}
+
+	// a match by ID outweighs any number of matches by similarity
+	idScore := float64(n + m + 1)
+	score := func(i, j int) float64 {
+		old, block := existing[i], desired[j]
+		if old.GetType() != block.GetType() {
+			return math.Inf(-1)
+		}
+		if id := block.GetID(); id != "" {
+			if id == old.GetID() {
+				return idScore
+			}
+			return math.Inf(-1)
+		}
+		if oldValid[i] && newValid[j] && reflect.DeepEqual(oldContents[i], newContents[j]) {
+			return 1
+		}
+		if s := textSimilarity(oldTexts[i], newTexts[j]); s >= d.similarity {
+			return s
+		}
+		return math.Inf(-1)
+	}
+
+	// best[i][j] is the best total score of matching existing[i:] and desired[j:]
+	best := make([][]float64, n+1)
+	for i := range best {
+		best[i] = make([]float64, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			best[i][j] = max(best[i+1][j], best[i][j+1], score(i, j)+best[i+1][j+1])
+		}
+	}
+
+	matches := blockMatches{old: make([]int, n), new: make([]int, m)}
+	for i := range matches.old {
+		matches.old[i] = -1
+	}
+	for j := range matches.new {
+		matches.new[j] = -1
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch best[i][j] {
+		case best[i+1][j]:
+			i++
+		case best[i][j+1]:
+			j++
+		default:
+			matches.old[i], matches.new[j] = j, i
+			i, j = i+1, j+1
+		}
+	}
+
+	d.pairGaps(existing, desired, matches)
+
+	// new blocks can't be inserted before the first existing block
+	if n > 0 && m > 0 && matches.new[0] < 0 && matches.old[0] >= 0 {
+		matches.new[matches.old[0]], matches.old[0] = -1, -1
+	}
+
+	return matches
+}
+
+// pairGaps matches the unmatched blocks of the same types between two matched pairs (to update them in place).
+func (d *differ) pairGaps(existing, desired Blocks, matches blockMatches) {
+	i, j := 0, 0
+	for i < len(existing) && j < len(desired) {
+		switch {
+		case matches.old[i] >= 0 && matches.new[j] >= 0:
+			i, j = i+1, j+1
+		case matches.old[i] >= 0:
+			j++
+		case matches.new[j] >= 0:
+			i++
+		case existing[i].GetType() == desired[j].GetType() && desired[j].GetID() == "" && isUpdatable(desired[j]):
+			matches.old[i], matches.new[j] = j, i
+			i, j = i+1, j+1
+		default:
+			// skip the existing block: the next one may be of the right type
+			i++
+		}
+	}
+}
+
+// inPlaceBlockTypes are the block types whose content the API updates in place.
+var inPlaceBlockTypes = map[BlockType]bool{
+	BlockTypeParagraph: true, BlockTypeHeading1: true, BlockTypeHeading2: true, BlockTypeHeading3: true,
+	BlockTypeBulletedListItem: true, BlockTypeNumberedListItem: true, BlockTypeToDo: true, BlockTypeToggle: true,
+	BlockTypeQuote: true, BlockTypeCallout: true, BlockTypeCode: true, BlockTypeEquation: true,
+	BlockTypeEmbed: true, BlockTypeBookmark: true, BlockTypeImage: true, BlockTypeVideo: true,
+	BlockTypeAudio: true, BlockTypeFile: true, BlockTypePdf: true, BlockTypeTableRow: true,
+	BlockTypeTemplate: true, BlockTypeLinkToPage: true,
+}
+
+// isUpdatable returns true if the block content can be updated in place.
+func isUpdatable(block Block) bool {
+	return inPlaceBlockTypes[block.GetType()]
+}
+
+// blockText returns the texts of the rich texts of the block content.
+func blockText(block Block) string {
+	content, err := blockContent(block)
+	if err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	collectText(content, &sb)
+	return sb.String()
+}
+
+// collectText writes the texts of the rich texts found in the value.
+func collectText(v any, sb *strings.Builder) {
+	switch v := v.(type) {
+	case map[string]any:
+		if text, ok := v["text"].(map[string]any); ok {
+			fmt.Fprint(sb, text["content"], " ")
+			return
+		}
+		if equation, ok := v["equation"].(map[string]any); ok {
+			fmt.Fprint(sb, equation["expression"], " ")
+			return
+		}
+		if _, ok := v["mention"]; ok {
+			fmt.Fprint(sb, v["plain_text"], " ")
+			return
+		}
+		for _, value := range v {
+			collectText(value, sb)
+		}
+	case []any:
+		for _, value := range v {
+			collectText(value, sb)
+		}
+	}
+}
+
+// textSimilarity returns the share of the words the texts have in common (from 0 to 1).
+func textSimilarity(a, b string) float64 {
+	wordsA, wordsB := strings.Fields(strings.ToLower(a)), strings.Fields(strings.ToLower(b))
+	if len(wordsA)+len(wordsB) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(wordsA))
+	for _, word := range wordsA {
+		counts[word]++
+	}
+	common := 0
+	for _, word := range wordsB {
+		if counts[word] > 0 {
+			counts[word]--
+			common++
+		}
+	}
+	return float64(2*common) / float64(len(wordsA)+len(wordsB))
+}
+
+// ApplyPatchOptions configures BlocksService.ApplyPatch.
+type ApplyPatchOptions struct {
+	// DryRun returns the plan (as ApplyPatchResult.Planned) instead of applying the patch.
+	DryRun bool
+	// Output is the optional writer for the plan of the dry run (it's BlockPatch.String).
+	Output io.Writer
+}
+
+// ApplyPatchResult is the result of BlocksService.ApplyPatch.
+type ApplyPatchResult struct {
+	// Applied is the number of the applied operations.
+	Applied int
+	// IDs maps the inserted blocks (at all levels) to their IDs.
+	IDs map[Block]BlockID
+	// Planned is the operations the dry run would apply.
+	Planned BlockPatch
+}
+
+// ApplyPatch applies the operations of the patch (see DiffBlocks) one by one.
+// It stops at the first error, returning the result of the operations applied so far.
+func (s *BlocksService) ApplyPatch(ctx context.Context, patch BlockPatch, opts *ApplyPatchOptions) (*ApplyPatchResult, error) {
+	if opts == nil {
+		opts = &ApplyPatchOptions{}
+	}
+	result := &ApplyPatchResult{IDs: make(map[Block]BlockID)}
+
+	if opts.DryRun {
+		result.Planned = patch
+		if opts.Output == nil {
+			return result, nil
+		}
+		_, err := io.WriteString(opts.Output, patch.String())
+		return result, err
+	}
+
+	for _, op := range patch {
+		var err error
+		switch op.Type {
+		case BlockOpUpdate:
+			_, err = s.Update(ctx, op.ID, op.Update)
+		case BlockOpInsert:
+			var ids map[Block]BlockID
+			ids, err = s.AppendTree(ctx, op.ParentID, op.Blocks, &AppendTreeOptions{After: op.After})
+			for block, id := range ids {
+				result.IDs[block] = id
+			}
+		case BlockOpArchive:
+			_, err = s.Delete(ctx, op.ID)
+		default:
+			err = fmt.Errorf("unknown block operation: %q", op.Type)
+		}
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+		result.Applied++
+	}
+
+	return result, nil
+}
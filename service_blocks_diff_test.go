@@ -0,0 +1,134 @@
+package notion_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func TestDiffBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	newPage := func(blocks ...notion.Block) (*notion.Page, notion.Blocks) {
+		page := newTestPage(t, srv, "Page")
+		srv.AddBlocks(page.ID, blocks...)
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		return page, tree
+	}
+
+	t.Run("should update, insert and archive", func(t *testing.T) {
+		page, existing := newPage(
+			toggle("alpha one"),
+			toggle("beta two", toggle("b1"), toggle("b2")),
+			toggle("gamma three"),
+			toggle("delta four"),
+		)
+		desired := notion.Blocks{
+			toggle("alpha one"),
+			toggle("beta two updated", toggle("b1"), toggle("b3")),
+			notion.NewParagraphBlock(notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText("new block")}}),
+			toggle("delta four"),
+		}
+
+		patch := notion.DiffBlocks(page.ID, existing, desired, nil)
+		beta, gamma := existing[1].GetID(), existing[2].GetID()
+		b2 := existing[1].(notion.HierarchicalBlock).GetChildren()[1].GetID()
+		assert.Equal(t, ""+
+			"update toggle "+beta.String()+"\n"+
+			"update toggle "+b2.String()+"\n"+
+			"insert paragraph into "+page.ID.String()+" after "+beta.String()+"\n"+
+			"archive toggle "+gamma.String()+"\n",
+			patch.String())
+
+		res, err := client.Blocks.ApplyPatch(ctx, patch, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Applied)
+		assert.Contains(t, res.IDs, desired[2])
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, outline(desired, ""), outline(tree, ""))
+		assert.Equal(t, existing[0].GetID(), tree[0].GetID())
+		assert.Equal(t, beta, tree[1].GetID())
+		assert.Equal(t, existing[3].GetID(), tree[3].GetID())
+
+		assert.Empty(t, notion.DiffBlocks(page.ID, tree, desired, nil))
+	})
+
+	t.Run("should match by IDs", func(t *testing.T) {
+		page, existing := newPage(toggle("first"), toggle("second"))
+
+		renamed := toggle("completely different")
+		renamed.ID = existing[1].GetID()
+
+		patch := notion.DiffBlocks(page.ID, existing, notion.Blocks{renamed}, nil)
+		require.Len(t, patch, 2)
+		assert.Equal(t, notion.BlockOpUpdate, patch[0].Type)
+		assert.Equal(t, existing[1].GetID(), patch[0].ID)
+		assert.Equal(t, notion.BlockOpArchive, patch[1].Type)
+		assert.Equal(t, existing[0].GetID(), patch[1].ID)
+	})
+
+	t.Run("should re-create the first block to insert before it", func(t *testing.T) {
+		page, existing := newPage(toggle("kept block"))
+		desired := notion.Blocks{
+			notion.NewParagraphBlock(notion.Paragraph{RichText: notion.RichTexts{notion.NewTextRichText("new")}}),
+			toggle("kept block"),
+		}
+
+		patch := notion.DiffBlocks(page.ID, existing, desired, nil)
+		require.Len(t, patch, 2)
+		assert.Equal(t, notion.BlockOpInsert, patch[0].Type)
+		assert.Equal(t, existing[0].GetID(), patch[0].After)
+		assert.Equal(t, notion.BlockOpArchive, patch[1].Type)
+
+		_, err := client.Blocks.ApplyPatch(ctx, patch, nil)
+		require.NoError(t, err)
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "paragraph\nkept block\n", outline(tree, ""))
+	})
+
+	t.Run("should keep the blocks", func(t *testing.T) {
+		page, existing := newPage(toggle("x"), notion.NewChildPageBlock("Sub"))
+
+		patch := notion.DiffBlocks(page.ID, existing, notion.Blocks{toggle("x")}, &notion.DiffOptions{Keep: notion.SkipChildPages})
+		assert.Empty(t, patch)
+	})
+
+	t.Run("should print the plan in dry-run mode", func(t *testing.T) {
+		page, existing := newPage(toggle("a"))
+		patch := notion.DiffBlocks(page.ID, existing, nil, nil)
+
+		var out bytes.Buffer
+		res, err := client.Blocks.ApplyPatch(ctx, patch, &notion.ApplyPatchOptions{DryRun: true, Output: &out})
+		require.NoError(t, err)
+		assert.Zero(t, res.Applied)
+		assert.Equal(t, patch, res.Planned)
+		assert.Equal(t, "archive toggle "+existing[0].GetID().String()+"\n", out.String())
+		assert.False(t, srv.Block(existing[0].GetID()).GetArchived())
+	})
+
+	t.Run("should return the plan of a dry run without output", func(t *testing.T) {
+		page, existing := newPage(toggle("planned"))
+		patch := notion.DiffBlocks(page.ID, existing, nil, nil)
+
+		res, err := client.Blocks.ApplyPatch(ctx, patch, &notion.ApplyPatchOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Zero(t, res.Applied)
+		require.Len(t, res.Planned, 1)
+		assert.Equal(t, notion.BlockOpArchive, res.Planned[0].Type)
+		assert.Equal(t, existing[0].GetID(), res.Planned[0].ID)
+		assert.False(t, srv.Block(existing[0].GetID()).GetArchived())
+	})
+}
@@ -3,12 +3,17 @@ package notion_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBlocksService(t *testing.T) {
@@ -202,3 +207,100 @@ func TestBlockUpdateRequest_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBlockUpdateRequest(t *testing.T) {
+	blockTypes := []notion.BlockType{
+		notion.BlockTypeParagraph, notion.BlockTypeHeading1, notion.BlockTypeHeading2, notion.BlockTypeHeading3,
+		notion.BlockTypeBulletedListItem, notion.BlockTypeNumberedListItem, notion.BlockTypeToDo, notion.BlockTypeToggle,
+		notion.BlockTypeChildPage, notion.BlockTypeChildDatabase, notion.BlockTypeEmbed, notion.BlockTypeImage,
+		notion.BlockTypeAudio, notion.BlockTypeVideo, notion.BlockTypeFile, notion.BlockTypePdf,
+		notion.BlockTypeBookmark, notion.BlockTypeCode, notion.BlockTypeDivider, notion.BlockTypeCallout,
+		notion.BlockTypeQuote, notion.BlockTypeTableOfContents, notion.BlockTypeEquation, notion.BlockTypeBreadcrumb,
+		notion.BlockTypeColumn, notion.BlockTypeColumnList, notion.BlockTypeLinkPreview, notion.BlockTypeLinkToPage,
+		notion.BlockTypeSyncedBlock, notion.BlockTypeTable, notion.BlockTypeTableRow, notion.BlockTypeTemplate,
+	}
+
+	fields := make(map[string]bool)
+	requestType := reflect.TypeOf(notion.BlockUpdateRequest{})
+	for i := range requestType.NumField() {
+		name, _, _ := strings.Cut(requestType.Field(i).Tag.Get("json"), ",")
+		fields[name] = true
+	}
+
+	t.Run("should cover all block types", func(t *testing.T) {
+		for _, blockType := range blockTypes {
+			t.Run(blockType.String(), func(t *testing.T) {
+				assert.True(t, fields[blockType.String()], "BlockUpdateRequest has no field for %s", blockType)
+
+				var blocks notion.Blocks
+				data := fmt.Sprintf(`[{"object":"block","id":"some_id","type":%q,%q:{"children":[]},"has_children":true}]`, blockType, blockType)
+				require.NoError(t, json.Unmarshal([]byte(data), &blocks))
+				require.Equal(t, blockType, blocks[0].GetType())
+
+				req, err := notion.NewBlockUpdateRequest(blocks[0])
+				require.NoError(t, err)
+				assert.Empty(t, req.Extra)
+
+				got, err := json.Marshal(req)
+				require.NoError(t, err)
+				var payload map[string]map[string]any
+				require.NoError(t, json.Unmarshal(got, &payload))
+				assert.Len(t, payload, 1)
+				require.Contains(t, payload, blockType.String())
+				assert.NotContains(t, payload[blockType.String()], "children")
+			})
+		}
+	})
+
+	t.Run("should build the request from the block content", func(t *testing.T) {
+		req, err := notion.NewBlockUpdateRequest(notion.NewChildPageBlock("Renamed"))
+		require.NoError(t, err)
+		got, err := json.Marshal(req)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"child_page":{"title":"Renamed"}}`, string(got))
+	})
+
+	t.Run("should merge extra payloads and the archived state", func(t *testing.T) {
+		archived := false
+		got, err := json.Marshal(&notion.BlockUpdateRequest{
+			Extra:    map[string]any{"button": map[string]any{}},
+			Archived: &archived,
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"button":{},"archived":false}`, string(got))
+	})
+
+	t.Run("should fail for unsupported blocks", func(t *testing.T) {
+		_, err := notion.NewBlockUpdateRequest(&notion.UnsupportedBlock{BasicBlock: notion.NewBasicBlock(notion.BlockTypeUnsupported)})
+		assert.Error(t, err)
+	})
+}
+
+func TestBlocksService_UpdateFrom(t *testing.T) {
+	ctx := context.Background()
+	srv, client := notiontest.New(t)
+
+	page := newTestPage(t, srv, "Page")
+	blocks := srv.AddBlocks(page.ID, notion.NewDividerBlock(), toggle("before", toggle("child")))
+
+	t.Run("should update the block content", func(t *testing.T) {
+		block := blocks[1].(*notion.ToggleBlock)
+		block.Toggle.RichText = notion.RichTexts{notion.NewTextRichText("after")}
+
+		updated, err := client.Blocks.UpdateFrom(ctx, block)
+		require.NoError(t, err)
+		assert.Equal(t, "after", updated.(*notion.ToggleBlock).Toggle.RichText[0].PlainText)
+		assert.Len(t, srv.Children(block.ID), 1)
+	})
+
+	t.Run("should restore archived blocks", func(t *testing.T) {
+		_, err := client.Blocks.Delete(ctx, blocks[0].GetID())
+		require.NoError(t, err)
+		require.True(t, srv.Block(blocks[0].GetID()).GetArchived())
+
+		restored, err := client.Blocks.Restore(ctx, blocks[0].GetID())
+		require.NoError(t, err)
+		assert.False(t, restored.GetArchived())
+		assert.False(t, srv.Block(blocks[0].GetID()).GetArchived())
+	})
+}
@@ -0,0 +1,568 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// DefaultTreeConcurrency is the default number of parallel requests of BlocksService.GetTree.
+// It matches the average rate limit, so requests are rarely throttled.
+const DefaultTreeConcurrency = DefaultRequestsPerSecond
+
+// GetTreeOptions configures BlocksService.GetTree.
+type GetTreeOptions struct {
+	// MaxDepth limits the depth of the fetched tree: 1 fetches only the children of the root block,
+	// 2 fetches their children as well, and so on. Zero means no limit.
+	MaxDepth int
+	// Concurrency is the maximum number of parallel requests. Zero means DefaultTreeConcurrency.
+	Concurrency int
+	// PageSize is the page size of children requests. Zero means the Notion default (100).
+	PageSize int
+	// Skip returns true for the blocks whose children must not be fetched (see SkipChildPages).
+	// It may be called concurrently.
+	Skip func(block Block) bool
+	// ResolveSynced attaches the children of original synced blocks to the synced blocks referencing them
+	// (whose own children are always empty). The children of each original block are fetched once,
+	// and the original block and each of its references get their own copies of them.
+	// The integration must have access to the original blocks.
+	ResolveSynced bool
+	// OnProgress is called each time children of a block are fetched.
+	// Calls are serialized, so the callback doesn't need to be safe for concurrent use.
+	OnProgress func(progress TreeProgress)
+}
+
+// TreeProgress describes the progress of BlocksService.GetTree.
+type TreeProgress struct {
+	// ParentID is the ID of the block whose children were just fetched.
+	ParentID BlockID
+	// Depth is the depth of the fetched children (1 for the children of the root block).
+	Depth int
+	// Children is the number of the fetched children.
+	Children int
+	// Blocks is the total number of blocks fetched so far.
+	Blocks int
+	// Requests is the total number of requests made so far.
+	Requests int
+	// Pending is the number of blocks whose children are still to be fetched.
+	Pending int
+}
+
+// SkipChildPages is a GetTreeOptions.Skip predicate that doesn't descend into child pages and databases.
+func SkipChildPages(block Block) bool {
+	switch block.GetType() {
+	case BlockTypeChildPage, BlockTypeChildDatabase:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetTree fetches the children of the block (or page) recursively and returns them
+// with nested children filled in via HierarchicalBlock.SetChildren.
+//
+// Children of different blocks are fetched in parallel (see GetTreeOptions.Concurrency).
+// Blocks that can't hold children in this SDK (e.g. child pages) are never descended into.
+// The first error cancels the remaining requests and is returned.
+func (s *BlocksService) GetTree(ctx context.Context, id BlockID, opts *GetTreeOptions) (Blocks, error) {
+	if opts == nil {
+		opts = &GetTreeOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultTreeConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	f := &treeFetcher{
+		service: s,
+		opts:    opts,
+		sem:     make(chan struct{}, concurrency),
+		cancel:  cancel,
+		synced:  make(map[BlockID]*syncedChildren),
+	}
+
+	var root Blocks
+	f.pending = 1
+	f.wg.Add(1)
+	go f.fetch(ctx, id, 1, func(children Blocks) { root = children })
+	f.wg.Wait()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	if err := f.copySyncedChildren(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// ResolveSynced fetches the children (of any depth) of the synced block and sets them to the block.
+// The children of a synced reference are the children of its original block.
+// Nested synced blocks are resolved as well (see GetTreeOptions.ResolveSynced).
+func (s *BlocksService) ResolveSynced(ctx context.Context, block Block) (Blocks, error) {
+	synced, ok := block.(*SyncedBlock)
+	if !ok {
+		return nil, fmt.Errorf("block %s is %s, not %s", block.GetID(), block.GetType(), BlockTypeSyncedBlock)
+	}
+
+	originalID := synced.GetID()
+	if synced.Synced.SyncedFrom != nil {
+		originalID = synced.Synced.SyncedFrom.BlockID
+	}
+	children, err := s.GetTree(ctx, originalID, &GetTreeOptions{ResolveSynced: true})
+	if err != nil {
+		return nil, err
+	}
+
+	synced.SetChildren(children)
+	return children, nil
+}
+
+// treeFetcher holds the state of a single GetTree call.
+type treeFetcher struct {
+	service *BlocksService
+	opts    *GetTreeOptions
+	sem     chan struct{}
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+
+	// mu guards the fields below and serializes OnProgress calls.
+	mu       sync.Mutex
+	err      error
+	blocks   int
+	requests int
+	pending  int
+	// synced holds the children of original synced blocks by their IDs (see GetTreeOptions.ResolveSynced).
+	synced map[BlockID]*syncedChildren
+}
+
+// syncedChildren are the children of an original synced block fetched for it and its references.
+type syncedChildren struct {
+	fetched  bool
+	children Blocks
+	// blocks are the synced blocks the children are set to.
+	blocks []*SyncedBlock
+}
+
+// fetch fetches all the children of the block, passes them to set
+// and starts fetching children of the children.
+func (f *treeFetcher) fetch(ctx context.Context, id BlockID, depth int, set func(Blocks)) {
+	defer f.wg.Done()
+
+	children, requests, err := f.fetchChildren(ctx, id)
+	if err != nil {
+		f.fail(err)
+		return
+	}
+	set(children)
+
+	descendants := make([]HierarchicalBlock, len(children))
+	synced := make([]*SyncedBlock, len(children))
+	for i, child := range children {
+		if f.shouldResolve(child, depth) {
+			synced[i] = child.(*SyncedBlock)
+		} else if f.shouldDescend(child, depth) {
+			descendants[i] = child.(HierarchicalBlock)
+		}
+	}
+
+	f.mu.Lock()
+	f.blocks += len(children)
+	f.requests += requests
+	f.pending--
+	// originals are the synced blocks whose children must be fetched for the synced blocks among the children
+	originals := make(map[BlockID]*syncedChildren)
+	for _, descendant := range descendants {
+		if descendant != nil {
+			f.pending++
+		}
+	}
+	for _, block := range synced {
+		if block == nil {
+			continue
+		}
+
+		originalID := block.GetID()
+		if block.Synced.SyncedFrom != nil {
+			originalID = block.Synced.SyncedFrom.BlockID
+		}
+		shared, ok := f.synced[originalID]
+		if !ok {
+			shared = &syncedChildren{}
+			f.synced[originalID] = shared
+			originals[originalID] = shared
+			f.pending++
+		}
+		if shared.fetched {
+			block.SetChildren(shared.children)
+		}
+		shared.blocks = append(shared.blocks, block)
+	}
+	if f.opts.OnProgress != nil && f.err == nil {
+		f.opts.OnProgress(TreeProgress{
+			ParentID: id,
+			Depth:    depth,
+			Children: len(children),
+			Blocks:   f.blocks,
+			Requests: f.requests,
+			Pending:  f.pending,
+		})
+	}
+	f.mu.Unlock()
+
+	for i, descendant := range descendants {
+		if descendant == nil {
+			continue
+		}
+		f.wg.Add(1)
+		go f.fetch(ctx, children[i].GetID(), depth+1, descendant.SetChildren)
+	}
+	for originalID, shared := range originals {
+		f.wg.Add(1)
+		go f.fetch(ctx, originalID, depth+1, func(children Blocks) { f.setSyncedChildren(shared, children) })
+	}
+}
+
+// setSyncedChildren sets the fetched children of an original synced block to the synced blocks found so far.
+// They share the children until copySyncedChildren.
+func (f *treeFetcher) setSyncedChildren(shared *syncedChildren, children Blocks) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	shared.fetched = true
+	shared.children = children
+	for _, block := range shared.blocks {
+		block.SetChildren(children)
+	}
+}
+
+// copySyncedChildren gives each synced block (but the first one) a deep copy of the children it shares
+// with the other blocks synced with it. It's called when the whole tree is fetched.
+func (f *treeFetcher) copySyncedChildren() error {
+	for _, shared := range f.synced {
+		for i := 1; i < len(shared.blocks); i++ {
+			children, err := copyBlocks(shared.children)
+			if err != nil {
+				return err
+			}
+			shared.blocks[i].SetChildren(children)
+		}
+	}
+	return nil
+}
+
+// copyBlocks returns a deep copy of the blocks (with all the fields).
+func copyBlocks(blocks Blocks) (Blocks, error) {
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		return nil, err
+	}
+	var copies Blocks
+	if err := json.Unmarshal(data, &copies); err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+// fetchChildren fetches all the pages of the block children, one request at a time.
+// It returns the children and the number of made requests.
+func (f *treeFetcher) fetchChildren(ctx context.Context, id BlockID) (Blocks, int, error) {
+	select {
+	case f.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+	defer func() { <-f.sem }()
+
+	children := make(Blocks, 0)
+	pagination := &Pagination{PageSize: f.opts.PageSize}
+	for requests := 1; ; requests++ {
+		res, err := f.service.GetChildren(ctx, id, pagination)
+		if err != nil {
+			return nil, requests, err
+		}
+		children = append(children, res.Results...)
+
+		if !res.HasMore || res.NextCursor == EmptyCursor {
+			return children, requests, nil
+		}
+		pagination.StartCursor = res.NextCursor
+	}
+}
+
+// shouldDescend returns true if children of the block (at the given depth) must be fetched.
+func (f *treeFetcher) shouldDescend(block Block, depth int) bool {
+	if !block.GetHasChildren() {
+		return false
+	}
+	if f.opts.MaxDepth > 0 && depth >= f.opts.MaxDepth {
+		return false
+	}
+	if f.opts.Skip != nil && f.opts.Skip(block) {
+		return false
+	}
+	return canHoldChildren(block.GetType())
+}
+
+// shouldResolve returns true if the block (at the given depth) is a synced block
+// whose children must be shared with the other blocks synced with it (see GetTreeOptions.ResolveSynced).
+func (f *treeFetcher) shouldResolve(block Block, depth int) bool {
+	synced, ok := block.(*SyncedBlock)
+	if !f.opts.ResolveSynced || !ok {
+		return false
+	}
+	if synced.Synced.SyncedFrom == nil && !synced.HasChildren {
+		return false
+	}
+	if f.opts.MaxDepth > 0 && depth >= f.opts.MaxDepth {
+		return false
+	}
+	return f.opts.Skip == nil || !f.opts.Skip(block)
+}
+
+// fail records the first error and cancels the remaining requests.
+func (f *treeFetcher) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err == nil {
+		f.err = err
+		f.cancel()
+	}
+}
+
+// canHoldChildren reports whether children can be set to blocks of the given type:
+// SetChildren of childfree blocks panics (see AtomNoChildren), so it's checked on a new empty block.
+func canHoldChildren(blockType BlockType) (ok bool) {
+	constructor, found := blockConstructor(blockType)
+	if !found {
+		return false
+	}
+	hierarchical, isHierarchical := constructor().(HierarchicalBlock)
+	if !isHierarchical {
+		return false
+	}
+
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	hierarchical.SetChildren(nil)
+
+	return true
+}
+
+// Limits of the append block children endpoint.
+//
+// See https://developers.notion.com/reference/request-limits#size-limits
+const (
+	// maxAppendChildren is the maximum number of children in a single children array.
+	maxAppendChildren = 100
+	// maxAppendBlocks is the maximum number of blocks (at all levels) in a single request.
+	maxAppendBlocks = 1000
+)
+
+// inlineChildrenLevels returns the number of children levels that must be sent
+// together with the block of the given type: tables can't be created without rows,
+// column lists without columns, and columns without their content.
+func inlineChildrenLevels(blockType BlockType) int {
+	switch blockType {
+	case BlockTypeTable:
+		return 1
+	case BlockTypeColumnList:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// AppendTreeOptions configures BlocksService.AppendTree.
+type AppendTreeOptions struct {
+	// After is the ID of the existing child block the blocks are inserted after.
+	// If empty, the blocks are appended to the end of the parent.
+	After BlockID
+}
+
+// AppendTree appends the blocks with their nested children of any depth to the parent block (or page).
+//
+// Unlike AppendChildren, it's not limited to 100 children and two levels of nesting:
+// blocks are appended level by level in compliant requests, deeper levels are appended to the created blocks.
+// The order of the blocks is preserved.
+//
+// It returns the IDs of the created blocks (at all levels) by the given blocks.
+// On error the IDs of the blocks created so far are returned as well.
+func (s *BlocksService) AppendTree(ctx context.Context, parentID BlockID, blocks Blocks, opts *AppendTreeOptions) (map[Block]BlockID, error) {
+	if opts == nil {
+		opts = &AppendTreeOptions{}
+	}
+
+	a := &treeAppender{service: s, ids: make(map[Block]BlockID)}
+	err := a.append(ctx, parentID, blocks, opts.After)
+
+	return a.ids, err
+}
+
+// treeAppender holds the state of a single AppendTree call.
+type treeAppender struct {
+	service *BlocksService
+	ids     map[Block]BlockID
+}
+
+// append appends the blocks to the parent in batches, chained by After.
+func (a *treeAppender) append(ctx context.Context, parentID BlockID, blocks Blocks, after BlockID) error {
+	for len(blocks) > 0 {
+		batch, payload, err := nextAppendBatch(blocks)
+		if err != nil {
+			return err
+		}
+
+		res, err := a.service.AppendChildren(ctx, parentID, &AppendBlockChildrenRequest{After: after, Children: payload})
+		if err != nil {
+			return err
+		}
+		if len(res.Results) != len(batch) {
+			return fmt.Errorf("appended %d blocks to %s, but %d were created", len(batch), parentID, len(res.Results))
+		}
+
+		for i, block := range batch {
+			id := res.Results[i].GetID()
+			a.ids[block] = id
+			if err := a.appendChildren(ctx, block, id, inlineChildrenLevels(block.GetType())); err != nil {
+				return err
+			}
+		}
+
+		after = res.Results[len(res.Results)-1].GetID()
+		blocks = blocks[len(batch):]
+	}
+
+	return nil
+}
+
+// appendChildren appends the children of the created block which were not sent with it.
+// levels is the number of the children levels sent inline (see inlineChildrenLevels):
+// the IDs of the inline children are fetched to continue with their own children.
+func (a *treeAppender) appendChildren(ctx context.Context, block Block, id BlockID, levels int) error {
+	children := childrenOf(block)
+	if len(children) == 0 {
+		return nil
+	}
+	if levels == 0 {
+		return a.append(ctx, id, children, "")
+	}
+
+	sent := min(len(children), maxAppendChildren)
+	created := make(Blocks, 0, sent)
+	for child, err := range a.service.AllChildren(ctx, id, nil) {
+		if err != nil {
+			return err
+		}
+		created = append(created, child)
+	}
+	if len(created) != sent {
+		return fmt.Errorf("appended %d children to %s, but %d were created", sent, id, len(created))
+	}
+
+	for i, child := range children[:sent] {
+		a.ids[child] = created[i].GetID()
+		if err := a.appendChildren(ctx, child, created[i].GetID(), levels-1); err != nil {
+			return err
+		}
+	}
+
+	return a.append(ctx, id, children[sent:], created[sent-1].GetID())
+}
+
+// nextAppendBatch returns the blocks that fit into a single append request
+// and the payload: their copies with the inline children only (see inlineChildrenLevels).
+func nextAppendBatch(blocks Blocks) (Blocks, Blocks, error) {
+	var count int
+	payload := make(Blocks, 0, min(len(blocks), maxAppendChildren))
+	for _, block := range blocks[:min(len(blocks), maxAppendChildren)] {
+		levels := inlineChildrenLevels(block.GetType())
+		size := countInlineBlocks(block, levels)
+		if count+size > maxAppendBlocks && len(payload) > 0 {
+			break
+		}
+		count += size
+
+		truncated, err := truncateChildren(block, levels)
+		if err != nil {
+			return nil, nil, err
+		}
+		payload = append(payload, truncated)
+	}
+
+	return blocks[:len(payload)], payload, nil
+}
+
+// countInlineBlocks returns the number of blocks sent in a request for the block
+// with the given number of inline children levels.
+func countInlineBlocks(block Block, levels int) int {
+	count := 1
+	if levels == 0 {
+		return count
+	}
+
+	children := childrenOf(block)
+	for _, child := range children[:min(len(children), maxAppendChildren)] {
+		count += countInlineBlocks(child, levels-1)
+	}
+	return count
+}
+
+// truncateChildren returns a copy of the block with the given number of children levels.
+// At most maxAppendChildren children are kept on each level.
+func truncateChildren(block Block, levels int) (Block, error) {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	truncateRawChildren(raw, levels)
+
+	if data, err = json.Marshal(raw); err != nil {
+		return nil, err
+	}
+	return decodeBlock(data)
+}
+
+// truncateRawChildren truncates children of the JSON-decoded block in place.
+func truncateRawChildren(raw map[string]any, levels int) {
+	blockType, _ := raw["type"].(string)
+	content, ok := raw[blockType].(map[string]any)
+	if !ok {
+		return
+	}
+
+	children, _ := content["children"].([]any)
+	if levels == 0 || len(children) == 0 {
+		delete(content, "children")
+		return
+	}
+
+	children = children[:min(len(children), maxAppendChildren)]
+	for _, child := range children {
+		if childRaw, ok := child.(map[string]any); ok {
+			truncateRawChildren(childRaw, levels-1)
+		}
+	}
+	content["children"] = children
+}
+
+// childrenOf returns the children of the block, if it can have any.
+func childrenOf(block Block) Blocks {
+	if hierarchical, ok := block.(HierarchicalBlock); ok {
+		return hierarchical.GetChildren()
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package notion_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+// toggle returns a new toggle block with the given text and children.
+func toggle(text string, children ...notion.Block) *notion.ToggleBlock {
+	t := notion.Toggle{RichText: notion.RichTexts{notion.NewTextRichText(text)}}
+	t.SetChildren(children)
+	return notion.NewToggleBlock(t)
+}
+
+// outline returns the texts of toggles in the tree, indented by depth. Other blocks are shown by type.
+func outline(blocks notion.Blocks, indent string) string {
+	var sb strings.Builder
+	for _, block := range blocks {
+		sb.WriteString(indent)
+		if t, ok := block.(*notion.ToggleBlock); ok {
+			sb.WriteString(t.Toggle.RichText[0].PlainText)
+		} else {
+			sb.WriteString(block.GetType().String())
+		}
+		sb.WriteString("\n")
+		if h, ok := block.(notion.HierarchicalBlock); ok {
+			sb.WriteString(outline(h.GetChildren(), indent+"  "))
+		}
+	}
+	return sb.String()
+}
+
+// concurrencyTransport counts requests in flight and fails requests to the given path.
+type concurrencyTransport struct {
+	transport http.RoundTripper
+	failPath  string
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	requests    atomic.Int64
+}
+
+func (c *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	if c.failPath != "" && strings.HasPrefix(req.URL.Path, c.failPath) {
+		return nil, http.ErrHandlerTimeout
+	}
+
+	c.mu.Lock()
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	return c.transport.RoundTrip(req)
+}
+
+func TestBlocksService_GetTree(t *testing.T) {
+	ctx := context.Background()
+
+	srv := notiontest.NewServer()
+	defer srv.Close()
+
+	page := newTestPage(t, srv, "Root")
+	srv.AddBlocks(page.ID,
+		toggle("1", toggle("1.1", toggle("1.1.1")), toggle("1.2")),
+		toggle("2"),
+		toggle("3", toggle("3.1"), toggle("3.2"), toggle("3.3")),
+	)
+	subPage := srv.AddPage(&notion.PageCreateRequest{
+		Parent: notion.NewPageParent(page.ID),
+		Properties: notion.Properties{
+			"title": &notion.TitleProperty{Title: notion.RichTexts{notion.NewTextRichText("Sub")}},
+		},
+	})
+	srv.AddBlocks(subPage.ID, toggle("sub"))
+
+	newClient := func(transport *concurrencyTransport) *notion.Client {
+		transport.transport = http.DefaultTransport
+		return srv.Client(notion.WithTransport(transport))
+	}
+
+	t.Run("should fetch the whole tree", func(t *testing.T) {
+		transport := &concurrencyTransport{}
+		var progress []notion.TreeProgress
+
+		blocks, err := newClient(transport).Blocks.GetTree(ctx, page.ID, &notion.GetTreeOptions{
+			Concurrency: 2,
+			PageSize:    2,
+			OnProgress:  func(p notion.TreeProgress) { progress = append(progress, p) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1\n  1.1\n    1.1.1\n  1.2\n2\n3\n  3.1\n  3.2\n  3.3\nchild_page\n", outline(blocks, ""))
+
+		assert.LessOrEqual(t, transport.maxInFlight, 2)
+		// root (2 pages), 1, 1.1, 3 (2 pages); the child page can't hold children
+		require.Len(t, progress, 4)
+		last := progress[len(progress)-1]
+		assert.Equal(t, 10, last.Blocks)
+		assert.Equal(t, 0, last.Pending)
+		assert.Equal(t, int(transport.requests.Load()), last.Requests)
+		assert.Equal(t, 6, last.Requests)
+	})
+
+	t.Run("should limit depth", func(t *testing.T) {
+		blocks, err := newClient(&concurrencyTransport{}).Blocks.GetTree(ctx, page.ID, &notion.GetTreeOptions{MaxDepth: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "1\n  1.1\n  1.2\n2\n3\n  3.1\n  3.2\n  3.3\nchild_page\n", outline(blocks, ""))
+	})
+
+	t.Run("should skip blocks", func(t *testing.T) {
+		blocks, err := newClient(&concurrencyTransport{}).Blocks.GetTree(ctx, page.ID, &notion.GetTreeOptions{
+			Skip: func(block notion.Block) bool {
+				t, ok := block.(*notion.ToggleBlock)
+				return notion.SkipChildPages(block) || (ok && t.Toggle.RichText[0].PlainText == "1")
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1\n2\n3\n  3.1\n  3.2\n  3.3\nchild_page\n", outline(blocks, ""))
+	})
+
+	t.Run("should return the first error", func(t *testing.T) {
+		third := srv.Children(page.ID)[2]
+		transport := &concurrencyTransport{failPath: "/v1/blocks/" + third.GetID().String()}
+
+		blocks, err := newClient(transport).Blocks.GetTree(ctx, page.ID, nil)
+		require.ErrorIs(t, err, http.ErrHandlerTimeout)
+		assert.Nil(t, blocks)
+	})
+}
+
+// countBlocks returns the number of blocks in the tree.
+func countBlocks(blocks notion.Blocks) int {
+	count := len(blocks)
+	for _, block := range blocks {
+		if h, ok := block.(notion.HierarchicalBlock); ok {
+			count += countBlocks(h.GetChildren())
+		}
+	}
+	return count
+}
+
+func TestBlocksService_AppendTree(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	t.Run("should append deep and wide trees", func(t *testing.T) {
+		page := newTestPage(t, srv, "Page")
+
+		blocks := notion.Blocks{toggle("a", toggle("b", toggle("c", toggle("d", toggle("e")))))}
+		for i := range 150 {
+			blocks = append(blocks, toggle(strconv.Itoa(i)))
+		}
+
+		ids, err := client.Blocks.AppendTree(ctx, page.ID, blocks, nil)
+		require.NoError(t, err)
+		assert.Len(t, ids, countBlocks(blocks))
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, outline(blocks, ""), outline(tree, ""))
+
+		for block, id := range ids {
+			created := srv.Block(id)
+			require.NotNil(t, created)
+			assert.Equal(t, block.(*notion.ToggleBlock).Toggle.RichText[0].PlainText, created.(*notion.ToggleBlock).Toggle.RichText[0].PlainText)
+		}
+	})
+
+	t.Run("should append tables and columns with their content", func(t *testing.T) {
+		page := newTestPage(t, srv, "Page")
+
+		rows := make(notion.Blocks, 120)
+		for i := range rows {
+			rows[i] = notion.NewTableRowBlock(notion.TableRow{Cells: []notion.RichTexts{{notion.NewTextRichText(strconv.Itoa(i))}}})
+		}
+		column := func(children ...notion.Block) notion.Block {
+			return notion.NewColumnBlock(notion.Column{AtomChildren: notion.AtomChildren{Children: children}})
+		}
+		blocks := notion.Blocks{
+			notion.NewTableBlock(notion.Table{AtomChildren: notion.AtomChildren{Children: rows}, TableWidth: 1}),
+			notion.NewColumnListBlock(notion.ColumnList{AtomChildren: notion.AtomChildren{Children: notion.Blocks{
+				column(toggle("left", toggle("left.1", toggle("left.1.1")))),
+				column(toggle("right")),
+			}}}),
+		}
+
+		ids, err := client.Blocks.AppendTree(ctx, page.ID, blocks, nil)
+		require.NoError(t, err)
+		assert.Len(t, ids, countBlocks(blocks))
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, outline(blocks, ""), outline(tree, ""))
+
+		lastRow := srv.Block(ids[rows[119]]).(*notion.TableRowBlock)
+		assert.Equal(t, "119", lastRow.TableRow.Cells[0][0].PlainText)
+	})
+
+	t.Run("should insert after the given block", func(t *testing.T) {
+		page := newTestPage(t, srv, "Page")
+		existing := srv.AddBlocks(page.ID, toggle("first"), toggle("last"))
+
+		_, err := client.Blocks.AppendTree(ctx, page.ID, notion.Blocks{toggle("x", toggle("x.1")), toggle("y")},
+			&notion.AppendTreeOptions{After: existing[0].GetID()})
+		require.NoError(t, err)
+
+		tree, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "first\nx\n  x.1\ny\nlast\n", outline(tree, ""))
+	})
+
+	t.Run("should return error", func(t *testing.T) {
+		ids, err := client.Blocks.AppendTree(ctx, "00000000-0000-0000-0000-000000000000", notion.Blocks{toggle("x")}, nil)
+		require.ErrorIs(t, err, notion.ErrObjectNotFound)
+		assert.Empty(t, ids)
+	})
+}
+
+func TestBlocksService_ResolveSynced(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	reference := func(original notion.Block) *notion.SyncedBlock {
+		return notion.NewSyncedBlock(notion.Synced{SyncedFrom: &notion.SyncedFrom{BlockID: original.GetID()}})
+	}
+
+	source := newTestPage(t, srv, "Source")
+	original := srv.AddBlocks(source.ID, notion.NewSyncedBlock(notion.Synced{
+		AtomChildren: notion.AtomChildren{Children: notion.Blocks{toggle("s1", toggle("s1.1"))}},
+	}))[0]
+
+	page := newTestPage(t, srv, "Page")
+	srv.AddBlocks(page.ID, reference(original), toggle("t", reference(original)))
+
+	t.Run("should resolve the references in the tree once", func(t *testing.T) {
+		var requests int
+		blocks, err := client.Blocks.GetTree(ctx, page.ID, &notion.GetTreeOptions{
+			ResolveSynced: true,
+			OnProgress:    func(p notion.TreeProgress) { requests = p.Requests },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "synced_block\n  s1\n    s1.1\nt\n  synced_block\n    s1\n      s1.1\n", outline(blocks, ""))
+		assert.True(t, blocks[0].GetHasChildren())
+
+		// the page, "t", the original block and "s1"
+		assert.Equal(t, 4, requests)
+
+		// each reference has its own copy of the children
+		first := blocks[0].(notion.HierarchicalBlock).GetChildren()
+		nested := blocks[1].(notion.HierarchicalBlock).GetChildren()[0].(notion.HierarchicalBlock).GetChildren()
+		assert.NotSame(t, first[0], nested[0])
+		assert.Equal(t, first[0].GetID(), nested[0].GetID())
+		first[0].(notion.HierarchicalBlock).SetChildren(nil)
+		assert.Equal(t, "s1\n  s1.1\n", outline(nested, ""))
+	})
+
+	t.Run("should keep the references empty by default", func(t *testing.T) {
+		blocks, err := client.Blocks.GetTree(ctx, page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "synced_block\nt\n  synced_block\n", outline(blocks, ""))
+	})
+
+	t.Run("should resolve the synced block", func(t *testing.T) {
+		blocks, err := client.Blocks.GetChildren(ctx, page.ID, nil)
+		require.NoError(t, err)
+
+		children, err := client.Blocks.ResolveSynced(ctx, blocks.Results[0])
+		require.NoError(t, err)
+		assert.Equal(t, "s1\n  s1.1\n", outline(children, ""))
+		assert.Equal(t, children, blocks.Results[0].(notion.HierarchicalBlock).GetChildren())
+
+		_, err = client.Blocks.ResolveSynced(ctx, blocks.Results[1])
+		require.Error(t, err)
+	})
+}
@@ -2,8 +2,7 @@ package notion
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"iter"
 	"net/http"
 )
 
@@ -34,24 +33,14 @@ func newCommentsService(api *clientAPI) *CommentsService {
 //
 // See https://developers.notion.com/reference/create-a-comment
 func (s *CommentsService) Create(ctx context.Context, requestBody *CommentCreateRequest) (*Comment, error) {
-	res, err := s.api.request(ctx, http.MethodPost, pathComments, nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response Comment
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return call[*Comment](ctx, s.api, &Operation{
+		Service:    ServiceComments,
+		Method:     "Create",
+		HTTPMethod: http.MethodPost,
+		Path:       pathComments,
+		Payload:    requestBody,
+		decode:     decodeJSON[Comment],
+	})
 }
 
 // Get retrieves a list of un-resolved Comment objects from a page or block.
@@ -65,25 +54,28 @@ func (s *CommentsService) Get(ctx context.Context, id ObjectID, pagination *Pagi
 
 	queryParams["block_id"] = id.String()
 
-	res, err := s.api.request(ctx, http.MethodGet, pathComments, queryParams, nil)
-	if err != nil {
-		return nil, err
-	}
+	return call[*CommentQueryResponse](ctx, s.api, &Operation{
+		Service:    ServiceComments,
+		Method:     "Get",
+		ObjectID:   id,
+		HTTPMethod: http.MethodGet,
+		Path:       pathComments,
+		Query:      queryParams,
+		decode:     decodeJSON[CommentQueryResponse],
+	})
+}
 
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
+// GetAll returns an iterator over all the un-resolved comments of the page or block.
+// It requests the next pages of comments while iterating, starting with pagination.StartCursor.
+// pagination.PageSize (if set) is used as the size of each requested page.
+func (s *CommentsService) GetAll(ctx context.Context, id ObjectID, pagination *Pagination) iter.Seq2[*Comment, error] {
+	return paginate(pagination.startCursor(), func(cursor Cursor) ([]*Comment, AtomPaginatedResponse, error) {
+		res, err := s.Get(ctx, id, pagination.withCursor(cursor))
+		if err != nil {
+			return nil, AtomPaginatedResponse{}, err
 		}
-	}()
-
-	var response CommentQueryResponse
-
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+		return res.Results, res.AtomPaginatedResponse, nil
+	})
 }
 
 // CommentCreateRequest represents the request body for CommentClient.Create.
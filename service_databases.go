@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"iter"
 	"net/http"
 )
 
@@ -29,24 +29,14 @@ func newDatabasesService(api *clientAPI) *DatabasesService {
 //
 // See https://developers.notion.com/reference/create-a-database
 func (s *DatabasesService) Create(ctx context.Context, requestBody *DatabaseCreateRequest) (*Database, error) {
-	res, err := s.api.request(ctx, http.MethodPost, pathDatabases, nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response Database
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return call[*Database](ctx, s.api, &Operation{
+		Service:    ServiceDatabases,
+		Method:     "Create",
+		HTTPMethod: http.MethodPost,
+		Path:       pathDatabases,
+		Payload:    requestBody,
+		decode:     decodeJSON[Database],
+	})
 }
 
 // Query gets a list of Pages contained in the database, filtered and ordered
@@ -66,24 +56,35 @@ func (s *DatabasesService) Create(ctx context.Context, requestBody *DatabaseCrea
 //
 // See https://developers.notion.com/reference/post-database-query
 func (s *DatabasesService) Query(ctx context.Context, id DatabaseID, requestBody *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
-	res, err := s.api.request(ctx, http.MethodPost, fmt.Sprintf(pathDatabases+"/%s/query", id.String()), nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
+	return call[*DatabaseQueryResponse](ctx, s.api, &Operation{
+		Service:    ServiceDatabases,
+		Method:     "Query",
+		ObjectID:   id,
+		HTTPMethod: http.MethodPost,
+		Path:       fmt.Sprintf(pathDatabases+"/%s/query", id.String()),
+		Payload:    requestBody,
+		decode:     decodeJSON[DatabaseQueryResponse],
+	})
+}
 
-	var response DatabaseQueryResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
+// QueryAll returns an iterator over all the pages matching the query.
+// It requests the next pages of results while iterating, starting with requestBody.StartCursor.
+// requestBody.PageSize (if set) is used as the size of each requested page. requestBody is not modified.
+func (s *DatabasesService) QueryAll(ctx context.Context, id DatabaseID, requestBody *DatabaseQueryRequest) iter.Seq2[*Page, error] {
+	request := DatabaseQueryRequest{}
+	if requestBody != nil {
+		request = *requestBody
 	}
 
-	return &response, nil
+	return paginate(request.StartCursor, func(cursor Cursor) ([]*Page, AtomPaginatedResponse, error) {
+		pageRequest := request
+		pageRequest.StartCursor = cursor
+		res, err := s.Query(ctx, id, &pageRequest)
+		if err != nil {
+			return nil, AtomPaginatedResponse{}, err
+		}
+		return res.Results, res.AtomPaginatedResponse, nil
+	})
 }
 
 // Get gets a database by ID.
@@ -94,47 +95,29 @@ func (s *DatabasesService) Get(ctx context.Context, id DatabaseID) (*Database, e
 		return nil, errors.New("empty database id")
 	}
 
-	res, err := s.api.request(ctx, http.MethodGet, fmt.Sprintf(pathDatabases+"/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response Database
-
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return call[*Database](ctx, s.api, &Operation{
+		Service:    ServiceDatabases,
+		Method:     "Get",
+		ObjectID:   id,
+		HTTPMethod: http.MethodGet,
+		Path:       fmt.Sprintf(pathDatabases+"/%s", id.String()),
+		decode:     decodeJSON[Database],
+	})
 }
 
 // Update updates a Database by id
 //
 // https://developers.notion.com/reference/update-a-database
 func (s *DatabasesService) Update(ctx context.Context, id DatabaseID, requestBody *DatabaseUpdateRequest) (*Database, error) {
-	res, err := s.api.request(ctx, http.MethodPatch, fmt.Sprintf(pathDatabases+"/%s", id.String()), nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response Database
-	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
-		return nil, err
-	}
-	return &response, nil
+	return call[*Database](ctx, s.api, &Operation{
+		Service:    ServiceDatabases,
+		Method:     "Update",
+		ObjectID:   id,
+		HTTPMethod: http.MethodPatch,
+		Path:       fmt.Sprintf(pathDatabases+"/%s", id.String()),
+		Payload:    requestBody,
+		decode:     decodeJSON[Database],
+	})
 }
 
 // DatabaseCreateRequest represents the request body for DatabaseClient.Create.
@@ -250,3 +250,7 @@ func TestDatabaseQueryRequest_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkDatabaseUnmarshal(b *testing.B) {
+	benchmarkUnmarshal[notion.Database](b, "testdata/database_get.json")
+}
@@ -2,9 +2,7 @@ package notion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 )
 
@@ -38,18 +36,14 @@ func newPagesService(api *clientAPI) *PagesService {
 //
 // See https://developers.notion.com/reference/post-page
 func (s *PagesService) Create(ctx context.Context, requestBody *PageCreateRequest) (*Page, error) {
-	res, err := s.api.request(ctx, http.MethodPost, pathPages, nil, requestBody)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	return handlePageResponse(res)
+	return call[*Page](ctx, s.api, &Operation{
+		Service:    ServicePages,
+		Method:     "Create",
+		HTTPMethod: http.MethodPost,
+		Path:       pathPages,
+		Payload:    requestBody,
+		decode:     decodeJSON[Page],
+	})
 }
 
 // Get retrieves a Page object using the ID specified.
@@ -63,18 +57,14 @@ func (s *PagesService) Create(ctx context.Context, requestBody *PageCreateReques
 //
 // See https://developers.notion.com/reference/get-page
 func (s *PagesService) Get(ctx context.Context, id PageID) (*Page, error) {
-	res, err := s.api.request(ctx, http.MethodGet, fmt.Sprintf(pathPages+"/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	return handlePageResponse(res)
+	return call[*Page](ctx, s.api, &Operation{
+		Service:    ServicePages,
+		Method:     "Get",
+		ObjectID:   id,
+		HTTPMethod: http.MethodGet,
+		Path:       fmt.Sprintf(pathPages+"/%s", id.String()),
+		decode:     decodeJSON[Page],
+	})
 }
 
 // Update updates the properties of a page in a database. The properties body param of
@@ -93,18 +83,15 @@ func (s *PagesService) Get(ctx context.Context, id PageID) (*Page, error) {
 //
 // See https://developers.notion.com/reference/patch-page
 func (s *PagesService) Update(ctx context.Context, id PageID, request *PageUpdateRequest) (*Page, error) {
-	res, err := s.api.request(ctx, http.MethodPatch, fmt.Sprintf(pathPages+"/%s", id.String()), nil, request)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	return handlePageResponse(res)
+	return call[*Page](ctx, s.api, &Operation{
+		Service:    ServicePages,
+		Method:     "Update",
+		ObjectID:   id,
+		HTTPMethod: http.MethodPatch,
+		Path:       fmt.Sprintf(pathPages+"/%s", id.String()),
+		Payload:    request,
+		decode:     decodeJSON[Page],
+	})
 }
 
 // PageCreateRequest represents the request body for PagesClient.Create.
@@ -142,13 +129,3 @@ type PageUpdateRequest struct {
 	// A cover image for the page. Only external file objects are supported.
 	Cover *File `json:"cover,omitempty"`
 }
-
-func handlePageResponse(res *http.Response) (*Page, error) {
-	var response Page
-	err := json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
-}
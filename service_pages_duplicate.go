@@ -0,0 +1,400 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// DuplicateIssueKind is a kind of DuplicateIssue.
+type DuplicateIssueKind string
+
+// nolint:revive
+const (
+	DuplicateIssueProperty DuplicateIssueKind = "property"
+	DuplicateIssueIcon     DuplicateIssueKind = "icon"
+	DuplicateIssueCover    DuplicateIssueKind = "cover"
+	DuplicateIssueBlock    DuplicateIssueKind = "block"
+)
+
+// DuplicateIssue describes a part of a source page that wasn't copied as is.
+type DuplicateIssue struct {
+	Kind DuplicateIssueKind
+	// PageID is the ID of the source page.
+	PageID PageID
+	// Property is the name of the source property (for DuplicateIssueProperty).
+	Property string
+	// Block is the source block (for DuplicateIssueBlock).
+	Block Block
+	// Reason explains what was done instead of copying.
+	Reason string
+}
+
+// String returns a human-readable description of the issue.
+func (i DuplicateIssue) String() string {
+	switch i.Kind {
+	case DuplicateIssueProperty:
+		return fmt.Sprintf("page %s: property %q: %s", i.PageID, i.Property, i.Reason)
+	case DuplicateIssueBlock:
+		return fmt.Sprintf("page %s: block %s %s: %s", i.PageID, i.Block.GetType(), i.Block.GetID(), i.Reason)
+	default:
+		return fmt.Sprintf("page %s: %s: %s", i.PageID, i.Kind, i.Reason)
+	}
+}
+
+// DuplicatePageOptions configures PagesService.Duplicate.
+type DuplicatePageOptions struct {
+	// SubPages duplicates the child pages recursively. Otherwise they are handled by the Uncopyable policy.
+	// The API can't create a child page in place, so duplicated sub-pages follow the rest of the content.
+	SubPages bool
+	// Uncopyable is the policy for the blocks that can't be re-created (see IsCopyable).
+	// LinkUncopyable is used by default.
+	Uncopyable UncopyablePolicy
+	CopyContentOptions
+}
+
+// DuplicatePageResult is the result of PagesService.Duplicate.
+type DuplicatePageResult struct {
+	// Page is the copy of the source page.
+	Page *Page
+	// Pages maps the IDs of the duplicated source pages (the page and its sub-pages) to the IDs of their copies.
+	Pages map[PageID]PageID
+	// Blocks maps the IDs of the copied source blocks to the IDs of their copies.
+	Blocks map[BlockID]BlockID
+	// Issues report everything that wasn't copied as is.
+	Issues []DuplicateIssue
+}
+
+// Duplicate copies the page with its properties, icon, cover and content to the new parent.
+//
+// Read-only properties (created_time, formula, rollup, etc.) are converted to writable values
+// (date, rich_text, number, checkbox, people) when the new parent database has a property
+// of the same name and the matching type; other properties missing in the destination are dropped.
+// Pages outside databases keep the title only. Icons and covers uploaded to Notion can't be copied.
+//
+// Everything dropped or replaced is reported in the result. If creating the copy fails,
+// the result holds the objects created so far.
+func (s *PagesService) Duplicate(ctx context.Context, pageID PageID, newParent Parent, opts *DuplicatePageOptions) (*DuplicatePageResult, error) {
+	if opts == nil {
+		opts = &DuplicatePageOptions{}
+	}
+	d := &pageDuplicator{
+		pages:     s,
+		blocks:    newBlocksService(s.api),
+		databases: newDatabasesService(s.api),
+		opts:      opts,
+		policy:    opts.Uncopyable,
+		created:   make(map[PageID]bool),
+		result: &DuplicatePageResult{
+			Pages:  make(map[PageID]PageID),
+			Blocks: make(map[BlockID]BlockID),
+		},
+	}
+	if d.policy == nil {
+		d.policy = LinkUncopyable
+	}
+
+	page, err := d.duplicate(ctx, pageID, newParent)
+	d.result.Page = page
+	return d.result, err
+}
+
+// pageDuplicator holds the state of a single Duplicate call.
+type pageDuplicator struct {
+	pages     *PagesService
+	blocks    *BlocksService
+	databases *DatabasesService
+	opts      *DuplicatePageOptions
+	policy    UncopyablePolicy
+	result    *DuplicatePageResult
+	// created are the IDs of the copies made by this call.
+	created map[PageID]bool
+}
+
+// duplicate copies the page and (optionally) its sub-pages.
+func (d *pageDuplicator) duplicate(ctx context.Context, pageID PageID, parent Parent) (*Page, error) {
+	src, err := d.pages.Get(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema PropertyConfigs
+	if parent.Type == ParentTypeDatabaseID {
+		db, err := d.databases.Get(ctx, parent.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+		schema = db.Properties
+	}
+
+	var subPages []PageID
+	c := newTreeCopier(func(block Block) (Block, error) {
+		if d.opts.SubPages && block.GetType() == BlockTypeChildPage {
+			// the copies made by this call are skipped, e.g. when the page is duplicated into its sub-page
+			if !d.created[block.GetID()] {
+				subPages = append(subPages, block.GetID())
+			}
+			return nil, nil
+		}
+		replacement, err := d.policy(block)
+		if err != nil {
+			return nil, err
+		}
+		reason := "skipped"
+		if replacement != nil {
+			reason = "replaced with " + replacement.GetType().String()
+		}
+		d.issue(DuplicateIssue{Kind: DuplicateIssueBlock, PageID: pageID, Block: block, Reason: reason})
+		return replacement, nil
+	}, d.opts.CopyContentOptions)
+
+	// read the content first: it's the part most likely to fail
+	tree, err := c.getTree(ctx, d.blocks, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	copies, err := c.copyTree(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := d.pages.Create(ctx, &PageCreateRequest{
+		Parent:     parent,
+		Properties: d.properties(src, schema),
+		Icon:       d.icon(src),
+		Cover:      d.cover(src),
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.result.Pages[pageID] = page.ID
+	d.created[page.ID] = true
+
+	ids, err := d.blocks.AppendTree(ctx, page.ID, copies, nil)
+	for block, id := range ids {
+		if sourceID, ok := c.sources[block]; ok {
+			d.result.Blocks[sourceID] = id
+		}
+	}
+	if err != nil {
+		return page, err
+	}
+
+	for _, subPageID := range subPages {
+		if _, err := d.duplicate(ctx, subPageID, NewPageParent(page.ID)); err != nil {
+			return page, err
+		}
+	}
+
+	return page, nil
+}
+
+// issue adds the issue to the result.
+func (d *pageDuplicator) issue(issue DuplicateIssue) {
+	d.result.Issues = append(d.result.Issues, issue)
+}
+
+// properties returns the writable values of the source properties that match the destination schema.
+// A nil schema stands for a parent outside databases: only the title can be set there.
+func (d *pageDuplicator) properties(src *Page, schema PropertyConfigs) Properties {
+	names := make([]string, 0, len(src.Properties))
+	for name := range src.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	result := make(Properties)
+	for _, name := range names {
+		value := src.Properties[name]
+		drop := func(reason string) {
+			d.issue(DuplicateIssue{Kind: DuplicateIssueProperty, PageID: src.ID, Property: name, Reason: reason})
+		}
+
+		writable, ok := writablePropertyValue(value)
+		if !ok {
+			drop(fmt.Sprintf("%s property can't be written", value.GetType()))
+			continue
+		}
+
+		target, targetType := name, PropertyConfigType(writable.GetType())
+		switch {
+		case schema == nil && writable.GetType() == PropertyTypeTitle:
+			target = string(PropertyTypeTitle)
+		case schema == nil:
+			drop("only the title can be set outside databases")
+			continue
+		case schema[name] != nil:
+			targetType = schema[name].GetType()
+		case writable.GetType() == PropertyTypeTitle:
+			target = titlePropertyName(schema)
+		default:
+			drop("no such property in the destination database")
+			continue
+		}
+
+		converted, ok := convertPropertyValue(writable, targetType)
+		if !ok {
+			drop(fmt.Sprintf("%s value can't be written to %s property", value.GetType(), targetType))
+			continue
+		}
+		if files, ok := converted.(*FilesProperty); ok {
+			converted = d.externalFiles(src.ID, name, files)
+		}
+		result[target] = converted
+	}
+
+	return result
+}
+
+// externalFiles drops the files uploaded to Notion: they can't be re-attached by their (expiring) URLs.
+func (d *pageDuplicator) externalFiles(pageID PageID, name string, files *FilesProperty) *FilesProperty {
+	external := make(Files, 0, len(files.Files))
+	for _, file := range files.Files {
+		if file.Type == FileTypeFile {
+			d.issue(DuplicateIssue{
+				Kind: DuplicateIssueProperty, PageID: pageID, Property: name,
+				Reason: "file uploaded to Notion can't be copied: " + file.GetURL(),
+			})
+			continue
+		}
+		external = append(external, file)
+	}
+	return &FilesProperty{Type: PropertyTypeFiles, Files: external}
+}
+
+// icon returns the icon of the source page if it can be set by the API.
+func (d *pageDuplicator) icon(src *Page) *Icon {
+	if src.Icon == nil {
+		return nil
+	}
+	if src.Icon.Type != EmojiTypeEmoji && src.Icon.Type != FileTypeExternal {
+		d.issue(DuplicateIssue{Kind: DuplicateIssueIcon, PageID: src.ID, Reason: fmt.Sprintf("%s icon can't be copied", src.Icon.Type)})
+		return nil
+	}
+	return src.Icon
+}
+
+// cover returns the cover of the source page if it can be set by the API.
+func (d *pageDuplicator) cover(src *Page) *File {
+	if src.Cover == nil {
+		return nil
+	}
+	if src.Cover.Type != FileTypeExternal {
+		d.issue(DuplicateIssue{Kind: DuplicateIssueCover, PageID: src.ID, Reason: fmt.Sprintf("%s cover can't be copied", src.Cover.Type)})
+		return nil
+	}
+	return src.Cover
+}
+
+// titlePropertyName returns the name of the title property of the schema.
+func titlePropertyName(schema PropertyConfigs) string {
+	for name, config := range schema {
+		if config.GetType() == PropertyConfigTypeTitle {
+			return name
+		}
+	}
+	return string(PropertyTypeTitle)
+}
+
+// writablePropertyValue returns a writable copy of the property value (without the property ID).
+// Computed values are converted to the writable types of their results: formulas and rollups
+// to rich_text, number, checkbox or date, timestamps to date, authors to people and unique IDs to rich_text.
+func writablePropertyValue(value Property) (Property, bool) {
+	switch p := value.(type) {
+	case *TitleProperty:
+		return &TitleProperty{Type: PropertyTypeTitle, Title: p.Title}, true
+	case *RichTextProperty:
+		return &RichTextProperty{Type: PropertyTypeRichText, RichText: p.RichText}, true
+	case *TextProperty:
+		return &RichTextProperty{Type: PropertyTypeRichText, RichText: p.Text}, true
+	case *NumberProperty:
+		return &NumberProperty{Type: PropertyTypeNumber, Number: p.Number}, true
+	case *SelectProperty:
+		// options are referenced by names: their IDs differ between databases
+		return &SelectProperty{Type: PropertyTypeSelect, Select: Option{Name: p.Select.Name}}, true
+	case *MultiSelectProperty:
+		options := make([]Option, len(p.MultiSelect))
+		for i, option := range p.MultiSelect {
+			options[i] = Option{Name: option.Name}
+		}
+		return &MultiSelectProperty{Type: PropertyTypeMultiSelect, MultiSelect: options}, true
+	case *StatusProperty:
+		return &StatusProperty{Type: PropertyTypeStatus, Status: Status{Name: p.Status.Name}}, true
+	case *DateProperty:
+		return &DateProperty{Type: PropertyTypeDate, Date: p.Date}, true
+	case *RelationProperty:
+		return &RelationProperty{Type: PropertyTypeRelation, Relation: p.Relation}, true
+	case *PeopleProperty:
+		return &PeopleProperty{Type: PropertyTypePeople, People: userRefs(p.People...)}, true
+	case *FilesProperty:
+		return &FilesProperty{Type: PropertyTypeFiles, Files: p.Files}, true
+	case *CheckboxProperty:
+		return &CheckboxProperty{Type: PropertyTypeCheckbox, Checkbox: p.Checkbox}, true
+	case *URLProperty:
+		return &URLProperty{Type: PropertyTypeURL, URL: p.URL}, true
+	case *EmailProperty:
+		return &EmailProperty{Type: PropertyTypeEmail, Email: p.Email}, true
+	case *PhoneNumberProperty:
+		return &PhoneNumberProperty{Type: PropertyTypePhoneNumber, PhoneNumber: p.PhoneNumber}, true
+	case *FormulaProperty:
+		switch p.Formula.Type {
+		case FormulaTypeString:
+			return &RichTextProperty{Type: PropertyTypeRichText, RichText: RichTexts{NewTextRichText(p.Formula.String)}}, true
+		case FormulaTypeNumber:
+			return &NumberProperty{Type: PropertyTypeNumber, Number: p.Formula.Number}, true
+		case FormulaTypeBoolean:
+			return &CheckboxProperty{Type: PropertyTypeCheckbox, Checkbox: p.Formula.Boolean}, true
+		case FormulaTypeDate:
+			return &DateProperty{Type: PropertyTypeDate, Date: p.Formula.Date}, true
+		}
+	case *RollupProperty:
+		switch p.Rollup.Type {
+		case RollupTypeNumber:
+			return &NumberProperty{Type: PropertyTypeNumber, Number: p.Rollup.Number}, true
+		case RollupTypeDate:
+			return &DateProperty{Type: PropertyTypeDate, Date: p.Rollup.Date}, true
+		}
+	case *CreatedTimeProperty:
+		start := Date(p.CreatedTime)
+		return &DateProperty{Type: PropertyTypeDate, Date: &DateObject{Start: &start}}, true
+	case *LastEditedTimeProperty:
+		start := Date(p.LastEditedTime)
+		return &DateProperty{Type: PropertyTypeDate, Date: &DateObject{Start: &start}}, true
+	case *CreatedByProperty:
+		return &PeopleProperty{Type: PropertyTypePeople, People: userRefs(&p.CreatedBy)}, true
+	case *LastEditedByProperty:
+		return &PeopleProperty{Type: PropertyTypePeople, People: userRefs(&p.LastEditedBy)}, true
+	case *UniqueIDProperty:
+		return &RichTextProperty{Type: PropertyTypeRichText, RichText: RichTexts{NewTextRichText(p.UniqueID.String())}}, true
+	}
+
+	return nil, false
+}
+
+// convertPropertyValue converts the writable value to the given property type.
+// Besides exact matches only titles and rich texts are interchangeable.
+func convertPropertyValue(value Property, target PropertyConfigType) (Property, bool) {
+	switch {
+	case PropertyConfigType(value.GetType()) == target:
+		return value, true
+	case target == PropertyConfigTypeTitle && value.GetType() == PropertyTypeRichText:
+		return &TitleProperty{Type: PropertyTypeTitle, Title: value.(*RichTextProperty).RichText}, true
+	case target == PropertyConfigTypeRichText && value.GetType() == PropertyTypeTitle:
+		return &RichTextProperty{Type: PropertyTypeRichText, RichText: value.(*TitleProperty).Title}, true
+	default:
+		return nil, false
+	}
+}
+
+// userRefs returns references to the users (only IDs are needed to set people properties).
+func userRefs(users ...*User) Users {
+	refs := make(Users, 0, len(users))
+	for _, user := range users {
+		if user == nil || user.ID == "" {
+			continue
+		}
+		refs = append(refs, &User{AtomObject: AtomObject{Object: ObjectTypeUser}, AtomID: AtomID{ID: user.ID}})
+	}
+	return refs
+}
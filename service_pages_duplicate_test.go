@@ -0,0 +1,148 @@
+package notion_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notion "github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
+)
+
+func TestPagesService_Duplicate(t *testing.T) {
+	ctx := context.Background()
+
+	srv, client := notiontest.New(t)
+	root := newTestPage(t, srv, "Root")
+	tasks := srv.AddDatabase(&notion.DatabaseCreateRequest{
+		Parent: notion.NewPageParent(root.ID),
+		Title:  notion.RichTexts{notion.NewTextRichText("Tasks")},
+		Properties: notion.PropertyConfigs{
+			"Name":    &notion.TitlePropertyConfig{Type: notion.PropertyConfigTypeTitle},
+			"Tags":    &notion.MultiSelectPropertyConfig{Type: notion.PropertyConfigTypeMultiSelect},
+			"Created": &notion.CreatedTimePropertyConfig{Type: notion.PropertyConfigCreatedTime},
+			"Code":    &notion.UniqueIDPropertyConfig{Type: notion.PropertyConfigUniqueID, UniqueID: notion.UniqueIDConfig{Prefix: "T"}},
+			"Total":   &notion.FormulaPropertyConfig{Type: notion.PropertyConfigTypeFormula},
+			"Notes":   &notion.RichTextPropertyConfig{Type: notion.PropertyConfigTypeRichText},
+		},
+	})
+	archive := srv.AddDatabase(&notion.DatabaseCreateRequest{
+		Parent: notion.NewPageParent(root.ID),
+		Title:  notion.RichTexts{notion.NewTextRichText("Archive")},
+		Properties: notion.PropertyConfigs{
+			"Task":    &notion.TitlePropertyConfig{Type: notion.PropertyConfigTypeTitle},
+			"Tags":    &notion.MultiSelectPropertyConfig{Type: notion.PropertyConfigTypeMultiSelect},
+			"Created": &notion.DatePropertyConfig{Type: notion.PropertyConfigTypeDate},
+			"Code":    &notion.RichTextPropertyConfig{Type: notion.PropertyConfigTypeRichText},
+			"Total":   &notion.NumberPropertyConfig{Type: notion.PropertyConfigTypeNumber},
+		},
+	})
+
+	src := srv.AddPage(&notion.PageCreateRequest{
+		Parent: notion.NewDatabaseParent(tasks.ID),
+		Properties: notion.Properties{
+			"Name": &notion.TitleProperty{Title: notion.RichTexts{notion.NewTextRichText("Task")}},
+			"Tags": &notion.MultiSelectProperty{MultiSelect: []notion.Option{{Name: "urgent"}}},
+		},
+		Icon: notion.NewEmojiIcon("🚀"),
+		Cover: &notion.File{
+			Type: notion.FileTypeFile,
+			File: &notion.FileData{URL: "https://files.notion.so/cover.png"},
+		},
+	})
+	srv.AddBlocks(src.ID,
+		toggle("a", toggle("b")),
+		notion.NewImageBlock(notion.File{
+			Type: notion.FileTypeFile,
+			File: &notion.FileData{URL: "https://files.notion.so/image.png"},
+		}),
+	)
+	sub := srv.AddPage(&notion.PageCreateRequest{
+		Parent: notion.NewPageParent(src.ID),
+		Properties: notion.Properties{
+			"title": &notion.TitleProperty{Title: notion.RichTexts{notion.NewTextRichText("Sub")}},
+		},
+	})
+	srv.AddBlocks(sub.ID, toggle("sub"))
+	srv.AddBlocks(src.ID, toggle("c"))
+
+	t.Run("should duplicate to another database with sub-pages", func(t *testing.T) {
+		res, err := client.Pages.Duplicate(ctx, src.ID, notion.NewDatabaseParent(archive.ID), &notion.DuplicatePageOptions{SubPages: true})
+		require.NoError(t, err)
+		require.NotNil(t, res.Page)
+		assert.Len(t, res.Pages, 2)
+		assert.Len(t, res.Blocks, 4)
+
+		copied := srv.Page(res.Page.ID)
+		assert.Equal(t, archive.ID, copied.Parent.DatabaseID)
+		assert.Equal(t, "Task", copied.Properties["Task"].(*notion.TitleProperty).Title[0].PlainText)
+		assert.Equal(t, "urgent", copied.Properties["Tags"].(*notion.MultiSelectProperty).MultiSelect[0].Name)
+		assert.Equal(t, "T-1", copied.Properties["Code"].(*notion.RichTextProperty).RichText[0].PlainText)
+		created := copied.Properties["Created"].(*notion.DateProperty).Date
+		require.NotNil(t, created)
+		assert.WithinDuration(t, *src.CreatedTime, time.Time(*created.Start), time.Second)
+		assert.Equal(t, notion.Emoji("🚀"), copied.Icon.Emoji)
+		assert.Nil(t, copied.Cover)
+
+		// sub-pages follow the rest of the content
+		tree, err := client.Blocks.GetTree(ctx, res.Page.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "a\n  b\nc\nchild_page\n", outline(tree, ""))
+		subCopy := tree[2]
+		assert.Equal(t, subCopy.GetID(), res.Pages[sub.ID])
+		assert.Equal(t, "sub\n", outline(srv.Children(subCopy.GetID()), ""))
+
+		kinds := make(map[notion.DuplicateIssueKind][]string)
+		for _, issue := range res.Issues {
+			assert.Equal(t, src.ID, issue.PageID)
+			kinds[issue.Kind] = append(kinds[issue.Kind], issue.Property)
+		}
+		assert.Equal(t, map[notion.DuplicateIssueKind][]string{
+			notion.DuplicateIssueBlock:    {""},
+			notion.DuplicateIssueCover:    {""},
+			notion.DuplicateIssueProperty: {"Notes", "Total"},
+		}, kinds)
+	})
+
+	t.Run("should keep the title only outside databases", func(t *testing.T) {
+		res, err := client.Pages.Duplicate(ctx, src.ID, notion.NewPageParent(root.ID), nil)
+		require.NoError(t, err)
+		assert.Len(t, res.Pages, 1)
+
+		copied := srv.Page(res.Page.ID)
+		require.Len(t, copied.Properties, 1)
+		assert.Equal(t, "Task", copied.Properties["title"].(*notion.TitleProperty).Title[0].PlainText)
+
+		tree, err := client.Blocks.GetTree(ctx, res.Page.ID, nil)
+		require.NoError(t, err)
+		require.Equal(t, "a\n  b\nlink_to_page\nc\n", outline(tree, ""))
+		assert.Equal(t, sub.ID, tree[1].(*notion.LinkToPageBlock).LinkToPage.PageID)
+
+		var properties []string
+		for _, issue := range res.Issues {
+			if issue.Kind == notion.DuplicateIssueProperty {
+				properties = append(properties, issue.Property)
+			}
+		}
+		assert.Equal(t, []string{"Code", "Created", "Notes", "Tags", "Total"}, properties)
+	})
+
+	t.Run("should not duplicate its own copies", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		// the copy becomes a sub-page of the sub-page it duplicates next
+		res, err := client.Pages.Duplicate(ctx, src.ID, notion.NewPageParent(sub.ID), &notion.DuplicatePageOptions{SubPages: true})
+		require.NoError(t, err)
+		assert.Len(t, res.Pages, 2)
+
+		tree, err := client.Blocks.GetTree(ctx, res.Page.ID, nil)
+		require.NoError(t, err)
+		require.Equal(t, "a\n  b\nc\nchild_page\n", outline(tree, ""))
+		assert.Equal(t, res.Pages[sub.ID], tree[2].GetID())
+		assert.Equal(t, "sub\n", outline(srv.Children(tree[2].GetID()), ""))
+	})
+}
@@ -208,8 +208,10 @@ func TestPagesService(t *testing.T) {
 				err: &notion.APIError{
 					Object:  notion.ObjectTypeError,
 					Status:  http.StatusBadRequest,
-					Code:    "validation_error",
+					Code:    notion.ErrorCodeValidation,
 					Message: "The provided page ID is not a valid Notion UUID: bla bla.",
+					Method:  http.MethodGet,
+					Path:    "/v1/pages/some_id",
 				},
 			},
 		}
@@ -580,3 +582,7 @@ func TestPageUpdateRequest_MarshallJSON(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkPageUnmarshal(b *testing.B) {
+	benchmarkUnmarshal[notion.Page](b, "testdata/page_get.json")
+}
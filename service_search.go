@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"iter"
 	"net/http"
 )
 
@@ -32,24 +32,34 @@ func newSearchService(api *clientAPI) *SearchService {
 //
 // See https://developers.notion.com/reference/post-search
 func (s *SearchService) Do(ctx context.Context, request *SearchRequest) (*SearchResponse, error) {
-	res, err := s.api.request(ctx, http.MethodPost, "search", nil, request)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
+	return call[*SearchResponse](ctx, s.api, &Operation{
+		Service:    ServiceSearch,
+		Method:     "Do",
+		HTTPMethod: http.MethodPost,
+		Path:       "search",
+		Payload:    request,
+		decode:     decodeJSON[SearchResponse],
+	})
+}
 
-	var response SearchResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
+// All returns an iterator over all the pages and databases found by the request.
+// It requests the next pages of results while iterating, starting with request.StartCursor.
+// request.PageSize (if set) is used as the size of each requested page. request is not modified.
+func (s *SearchService) All(ctx context.Context, request *SearchRequest) iter.Seq2[Object, error] {
+	searchRequest := SearchRequest{}
+	if request != nil {
+		searchRequest = *request
 	}
 
-	return &response, nil
+	return paginate(searchRequest.StartCursor, func(cursor Cursor) ([]Object, AtomPaginatedResponse, error) {
+		pageRequest := searchRequest
+		pageRequest.StartCursor = cursor
+		res, err := s.Do(ctx, &pageRequest)
+		if err != nil {
+			return nil, AtomPaginatedResponse{}, err
+		}
+		return res.Results, res.AtomPaginatedResponse, nil
+	})
 }
 
 // SearchRequest represents the request body for SearchClient.Do.
@@ -83,7 +93,7 @@ type SearchResponse struct {
 func (sr *SearchResponse) UnmarshalJSON(data []byte) error {
 	var tmp struct {
 		AtomPaginatedResponse
-		Results []any `json:"results"`
+		Results []json.RawMessage `json:"results"`
 	}
 
 	err := json.Unmarshal(data, &tmp)
@@ -92,22 +102,24 @@ func (sr *SearchResponse) UnmarshalJSON(data []byte) error {
 	}
 	objects := make(Objects, len(tmp.Results))
 	for i, rawObject := range tmp.Results {
+		var head struct {
+			Object ObjectType `json:"object"`
+		}
+		if err = json.Unmarshal(rawObject, &head); err != nil {
+			return err
+		}
+
 		var o Object
-		switch rawObject.(map[string]interface{})["object"].(string) {
-		case ObjectTypeDatabase.String():
+		switch head.Object {
+		case ObjectTypeDatabase:
 			o = &Database{}
-		case ObjectTypePage.String():
+		case ObjectTypePage:
 			o = &Page{}
 		default:
-			return fmt.Errorf("unsupported object type %s", rawObject.(map[string]interface{})["object"].(string))
-		}
-		j, err := json.Marshal(rawObject)
-		if err != nil {
-			return err
+			return fmt.Errorf("unsupported object type %s", head.Object)
 		}
 
-		err = json.Unmarshal(j, o)
-		if err != nil {
+		if err = json.Unmarshal(rawObject, o); err != nil {
 			return err
 		}
 		objects[i] = o
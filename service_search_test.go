@@ -53,3 +53,7 @@ func TestSearchClient(t *testing.T) {
 		}
 	})
 }
+
+func BenchmarkSearchResponseUnmarshal(b *testing.B) {
+	benchmarkUnmarshal[notion.SearchResponse](b, "testdata/search.json")
+}
@@ -2,9 +2,8 @@ package notion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
+	"iter"
 	"net/http"
 )
 
@@ -27,48 +26,41 @@ func newUsersService(api *clientAPI) *UsersService {
 //
 // See https://developers.notion.com/reference/get-users
 func (s *UsersService) List(ctx context.Context, pagination *Pagination) (*UsersListResponse, error) {
-	res, err := s.api.request(ctx, http.MethodGet, pathUsers, pagination.ToQuery(), nil)
-	if err != nil {
-		return nil, err
-	}
+	return call[*UsersListResponse](ctx, s.api, &Operation{
+		Service:    ServiceUsers,
+		Method:     "List",
+		HTTPMethod: http.MethodGet,
+		Path:       pathUsers,
+		Query:      pagination.ToQuery(),
+		decode:     decodeJSON[UsersListResponse],
+	})
+}
 
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
+// ListAll returns an iterator over all the Users of the workspace.
+// It requests the next pages of users while iterating, starting with pagination.StartCursor.
+// pagination.PageSize (if set) is used as the size of each requested page.
+func (s *UsersService) ListAll(ctx context.Context, pagination *Pagination) iter.Seq2[*User, error] {
+	return paginate(pagination.startCursor(), func(cursor Cursor) ([]*User, AtomPaginatedResponse, error) {
+		res, err := s.List(ctx, pagination.withCursor(cursor))
+		if err != nil {
+			return nil, AtomPaginatedResponse{}, err
 		}
-	}()
-
-	var response UsersListResponse
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+		return res.Results, AtomPaginatedResponse{Object: res.Object, NextCursor: res.NextCursor, HasMore: res.HasMore}, nil
+	})
 }
 
 // Get retrieves a User using the ID specified.
 //
 // See https://developers.notion.com/reference/get-user
 func (s *UsersService) Get(ctx context.Context, id UserID) (*User, error) {
-	res, err := s.api.request(ctx, http.MethodGet, fmt.Sprintf(pathUsers+"/%s", id.String()), nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response User
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return call[*User](ctx, s.api, &Operation{
+		Service:    ServiceUsers,
+		Method:     "Get",
+		ObjectID:   id,
+		HTTPMethod: http.MethodGet,
+		Path:       fmt.Sprintf(pathUsers+"/%s", id.String()),
+		decode:     decodeJSON[User],
+	})
 }
 
 // Me retrieves the bot User associated with the API token provided in the
@@ -77,24 +69,13 @@ func (s *UsersService) Get(ctx context.Context, id UserID) (*User, error) {
 //
 // See https://developers.notion.com/reference/get-self
 func (s *UsersService) Me(ctx context.Context) (*User, error) {
-	res, err := s.api.request(ctx, http.MethodGet, pathUsers+"/me", nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if errClose := res.Body.Close(); errClose != nil {
-			log.Println("failed to close body, should never happen")
-		}
-	}()
-
-	var response User
-	err = json.NewDecoder(res.Body).Decode(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	return &response, nil
+	return call[*User](ctx, s.api, &Operation{
+		Service:    ServiceUsers,
+		Method:     "Me",
+		HTTPMethod: http.MethodGet,
+		Path:       pathUsers + "/me",
+		decode:     decodeJSON[User],
+	})
 }
 
 // UsersListResponse stands for a paginated list of Users.
@@ -2,10 +2,12 @@ package notion_test
 
 import (
 	"encoding/json"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/amberpixels/notion-sdk-go"
+	"github.com/amberpixels/notion-sdk-go/notiontest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +45,30 @@ func newTestBasicBlock(id notion.BlockID, blockType notion.BlockType, timestamp
 		Type: blockType,
 	}
 }
+
+// newTestPage adds a workspace page with the given title to the fake server.
+func newTestPage(t *testing.T, srv *notiontest.Server, title string) *notion.Page {
+	t.Helper()
+	return srv.AddPage(&notion.PageCreateRequest{
+		Parent: notion.NewWorkspaceParent(),
+		Properties: notion.Properties{
+			"title": &notion.TitleProperty{Title: notion.RichTexts{notion.NewTextRichText(title)}},
+		},
+	})
+}
+
+// benchmarkUnmarshal benchmarks unmarshalling of the JSON file into a new T.
+func benchmarkUnmarshal[T any](b *testing.B, filePath string) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for range b.N {
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}